@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// answers holds the expected answer for each day and part of one set of
+// inputs.
+type answers map[answerKey]string
+
+type answerKey struct{ day, part int }
+
+// want returns the expected answer for the given day and part, and true iff
+// it is known.
+func (a answers) want(day, part int) (string, bool) {
+	s, ok := a[answerKey{day, part}]
+	return s, ok
+}
+
+// loadAnswers reads the answers manifest at the given path.
+func loadAnswers(path string) (answers, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return readAnswers(file)
+}
+
+// readAnswers parses an answers manifest.  Each line has a day, a part
+// and the expected answer, separated by whitespace.  The answer is the rest
+// of the line, so it may contain spaces.  Blank lines and lines that begin
+// with # are ignored.
+func readAnswers(r io.Reader) (answers, error) {
+	a := make(answers)
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		dayText, rest := cutField(text)
+		partText, answer := cutField(rest)
+		if answer == "" {
+			return nil, fmt.Errorf("line %d: want day, part and answer; got %q", line, text)
+		}
+
+		day, err := strconv.Atoi(dayText)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid day: %w", line, err)
+		}
+		part, err := strconv.Atoi(partText)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid part: %w", line, err)
+		}
+
+		key := answerKey{day, part}
+		if _, dup := a[key]; dup {
+			return nil, fmt.Errorf("line %d: duplicate answer for day %d part %d", line, day, part)
+		}
+		a[key] = answer
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// cutField splits the given text (which must not begin with a space) at the
+// end of its first field, and returns that field and the rest of the text
+// with the surrounding space removed.
+func cutField(text string) (field, rest string) {
+	i := strings.IndexFunc(text, unicode.IsSpace)
+	if i < 0 {
+		return text, ""
+	}
+	return text[:i], strings.TrimSpace(text[i:])
+}
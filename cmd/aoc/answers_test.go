@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAnswers(t *testing.T) {
+	t.Parallel()
+
+	in := `# day part answer
+19 1 1480
+
+19 2 3168
+25 1 2=-1=0
+10 2 EHZRBHPH  with  spaces
+`
+	got, err := readAnswers(strings.NewReader(in))
+	require.NoError(t, err)
+
+	want, ok := got.want(19, 2)
+	assert.True(t, ok)
+	assert.Equal(t, "3168", want)
+
+	want, ok = got.want(25, 1)
+	assert.True(t, ok)
+	assert.Equal(t, "2=-1=0", want)
+
+	want, ok = got.want(10, 2)
+	assert.True(t, ok)
+	assert.Equal(t, "EHZRBHPH  with  spaces", want)
+
+	_, ok = got.want(1, 1)
+	assert.False(t, ok)
+
+	for _, bad := range []string{"19 1", "19 1  ", "x 1 2", "19 y 2", "1 1 5\n1 1 6"} {
+		_, err := readAnswers(strings.NewReader(bad))
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestVerifyDay(t *testing.T) {
+	t.Parallel()
+
+	want := answers{
+		{2, 1}: "15",
+		{2, 2}: "13",
+	}
+
+	var (
+		buf   strings.Builder
+		tally verifyTally
+	)
+	err := verifyDay(context.Background(), 2, config{src: source{sample: true}}, want, &buf, io.Discard, &tally)
+	require.NoError(t, err)
+
+	assert.Equal(t, "day  2 part 1: pass\nday  2 part 2: fail (got 12, want 13)\n", buf.String())
+	assert.Equal(t, verifyTally{pass: 1, fail: 1}, tally)
+
+	buf.Reset()
+	tally = verifyTally{}
+	err = verifyDay(context.Background(), 2, config{part: 1, src: source{sample: true}}, answers{}, &buf, io.Discard, &tally)
+	require.NoError(t, err)
+	assert.Equal(t, "day  2 part 1: unknown (got 15)\n", buf.String())
+	assert.Equal(t, verifyTally{unknown: 1}, tally)
+}
+
+func TestVerifyDay_noInput(t *testing.T) {
+	t.Parallel()
+
+	var (
+		buf   strings.Builder
+		tally verifyTally
+	)
+	// the input for day 1 is not in this directory:
+	cfg := config{}
+	err := verifyDay(context.Background(), 1, cfg, answers{}, &buf, io.Discard, &tally)
+	require.NoError(t, err)
+	assert.Equal(t, "day  1 part 1: unknown (no input file)\nday  1 part 2: unknown (no input file)\n", buf.String())
+	assert.Equal(t, verifyTally{unknown: 2}, tally)
+
+	// but an input that was asked for by name must exist:
+	cfg = config{src: source{path: "no-such-file.txt"}}
+	err = verifyDay(context.Background(), 1, cfg, answers{}, &buf, io.Discard, &tally)
+	assert.Error(t, err)
+}
+
+func TestVerifyDay_solverError(t *testing.T) {
+	t.Parallel()
+
+	puzzle.RegisterSample(200, "x")
+	puzzle.Register(200,
+		func(context.Context, io.Reader) (any, error) { return nil, errors.New("oops") },
+		func(context.Context, io.Reader) (any, error) { return 2, nil })
+
+	var (
+		buf   strings.Builder
+		tally verifyTally
+	)
+	cfg := config{src: source{sample: true}}
+	err := verifyDay(context.Background(), 200, cfg, answers{{200, 2}: "2"}, &buf, io.Discard, &tally)
+	require.NoError(t, err)
+	assert.Equal(t, "day 200 part 1: fail (oops)\nday 200 part 2: pass\n", buf.String())
+	assert.Equal(t, verifyTally{pass: 1, fail: 1}, tally)
+}
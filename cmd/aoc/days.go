@@ -0,0 +1,28 @@
+package main
+
+// Each day's package registers its solvers with the puzzle package
+// when it is imported.
+import (
+	_ "github.com/nealmcc/aoc2022/cmd/day01"
+	_ "github.com/nealmcc/aoc2022/cmd/day02"
+	_ "github.com/nealmcc/aoc2022/cmd/day03"
+	_ "github.com/nealmcc/aoc2022/cmd/day05"
+	_ "github.com/nealmcc/aoc2022/cmd/day06"
+	_ "github.com/nealmcc/aoc2022/cmd/day08"
+	_ "github.com/nealmcc/aoc2022/cmd/day09"
+	_ "github.com/nealmcc/aoc2022/cmd/day11"
+	_ "github.com/nealmcc/aoc2022/cmd/day12"
+	_ "github.com/nealmcc/aoc2022/cmd/day13"
+	_ "github.com/nealmcc/aoc2022/cmd/day14"
+	_ "github.com/nealmcc/aoc2022/cmd/day15"
+	_ "github.com/nealmcc/aoc2022/cmd/day16"
+	_ "github.com/nealmcc/aoc2022/cmd/day17"
+	_ "github.com/nealmcc/aoc2022/cmd/day18"
+	_ "github.com/nealmcc/aoc2022/cmd/day19"
+	_ "github.com/nealmcc/aoc2022/cmd/day20"
+	_ "github.com/nealmcc/aoc2022/cmd/day21"
+	_ "github.com/nealmcc/aoc2022/cmd/day22"
+	_ "github.com/nealmcc/aoc2022/cmd/day23"
+	_ "github.com/nealmcc/aoc2022/cmd/day24"
+	_ "github.com/nealmcc/aoc2022/cmd/day25"
+)
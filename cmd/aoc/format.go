@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+)
+
+// resultWriter writes each result in one of the supported output formats.
+type resultWriter interface {
+	Write(puzzle.Result) error
+	Flush() error
+}
+
+// newResultWriter returns a writer for the named format: text, json or csv.
+func newResultWriter(format string, w io.Writer) (resultWriter, error) {
+	switch format {
+	case "text":
+		return textWriter{w}, nil
+	case "json":
+		return jsonWriter{json.NewEncoder(w)}, nil
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(_csvHeader); err != nil {
+			return nil, err
+		}
+		return csvWriter{cw}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// textWriter writes one human-readable line per result.
+type textWriter struct{ w io.Writer }
+
+func (t textWriter) Write(r puzzle.Result) error {
+	_, err := fmt.Fprintf(t.w, "day %2d part %d: %s in %s\n",
+		r.Day, r.Part, r.Answer, r.Duration)
+	return err
+}
+
+func (textWriter) Flush() error { return nil }
+
+// jsonWriter writes one JSON object per line.
+type jsonWriter struct{ enc *json.Encoder }
+
+// jsonResult is the JSON representation of a puzzle.Result.
+type jsonResult struct {
+	Day        int    `json:"day"`
+	Part       int    `json:"part"`
+	Answer     string `json:"answer"`
+	DurationNS int64  `json:"duration_ns"`
+	InputHash  string `json:"input_sha256"`
+}
+
+func (j jsonWriter) Write(r puzzle.Result) error {
+	return j.enc.Encode(jsonResult{
+		Day:        r.Day,
+		Part:       r.Part,
+		Answer:     r.Answer,
+		DurationNS: r.Duration.Nanoseconds(),
+		InputHash:  r.InputHash,
+	})
+}
+
+func (jsonWriter) Flush() error { return nil }
+
+// csvWriter writes a header row, followed by one row per result.
+type csvWriter struct{ w *csv.Writer }
+
+var _csvHeader = []string{"day", "part", "answer", "duration_ns", "input_sha256"}
+
+func (c csvWriter) Write(r puzzle.Result) error {
+	return c.w.Write([]string{
+		strconv.Itoa(r.Day),
+		strconv.Itoa(r.Part),
+		r.Answer,
+		strconv.FormatInt(r.Duration.Nanoseconds(), 10),
+		r.InputHash,
+	})
+}
+
+func (c csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
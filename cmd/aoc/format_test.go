@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultWriter(t *testing.T) {
+	t.Parallel()
+
+	res := puzzle.Result{
+		Day:       12,
+		Part:      2,
+		Answer:    "29",
+		Duration:  1500 * time.Microsecond,
+		InputHash: "abc123",
+	}
+
+	tt := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "text",
+			want:   "day 12 part 2: 29 in 1.5ms\n",
+		},
+		{
+			format: "json",
+			want: `{"day":12,"part":2,"answer":"29","duration_ns":1500000,` +
+				`"input_sha256":"abc123"}` + "\n",
+		},
+		{
+			format: "csv",
+			want: "day,part,answer,duration_ns,input_sha256\n" +
+				"12,2,29,1500000,abc123\n",
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.format, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+			w, err := newResultWriter(tc.format, buf)
+			require.NoError(t, err)
+			require.NoError(t, w.Write(res))
+			require.NoError(t, w.Flush())
+			assert.Equal(t, tc.want, buf.String())
+		})
+	}
+
+	_, err := newResultWriter("xml", new(bytes.Buffer))
+	assert.Error(t, err)
+}
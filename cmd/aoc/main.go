@@ -0,0 +1,474 @@
+// Command aoc runs the solvers for each day's puzzle.
+//
+// Usage:
+//
+//	aoc run <day>... [-part n] [-sample] [-format f] [-timeout d] [-progress]
+//	aoc run all [-part n] [-sample] [-format f] [-timeout d] [-progress]
+//	aoc run <day> -input <path> [-part n] [-format f] [-timeout d] [-progress]
+//	aoc verify <day>...|all [-part n] [-sample] [-answers path] [-timeout d]
+//
+// By default, the input for each day is read from cmd/dayNN/input.txt,
+// relative to the current directory.  Use -input to read a different file,
+// or "-input -" to read from stdin.  Use -sample to run each day with the
+// example input from its puzzle description instead, along with any settings
+// that differ for the example (such as a smaller area to search).
+//
+// The results are written to stdout as text, JSON lines or CSV, as chosen
+// by -format.  Each result includes the day, part, answer, duration and
+// the SHA-256 hash of the input.  Errors and other diagnostics are written
+// to stderr.
+//
+// The verify command runs the same solvers, and compares each answer to
+// the one recorded in an answers manifest, reporting pass, fail or unknown
+// for each part.  By default the manifest for the personal inputs is
+// answers.txt, and the manifest for the example inputs is answers_sample.txt.
+// The parts of a day without an input file (or example) are unknown.  Any
+// failure gives a non-zero exit status.
+//
+// Use -timeout to limit how long each part may run; a part that runs out of
+// time is reported as an error.  Use -progress to have the search-based
+// solvers report on their progress to stderr, about once per second.
+// Pressing Ctrl-C cancels the solver that is running.
+//
+// Use -param name=value to change one of the settings that some solvers
+// read, such as "beam" for the number of states that the searches in days 16
+// and 19 keep each minute (zero keeps them all, for an exact search).  It
+// may be given more than once.
+//
+// Some solvers can also save images or logs of how they found the answer:
+// use -out to choose a directory for them.  Use -animate to have the
+// solvers that can animate their work do so on stderr.  Both are counted in
+// the duration of each part.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nealmcc/aoc2022/pkg/progress"
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+)
+
+const _usage = `usage:
+  aoc run <day>... [-part n] [-sample] [-format text|json|csv]
+  aoc run all [-part n] [-sample] [-format text|json|csv]
+  aoc run <day> -input <path> [-part n] [-format text|json|csv]
+  aoc verify <day>...|all [-part n] [-sample] [-answers path]
+  aoc verify <day> -input <path> -answers <path> [-part n]
+
+  both commands also accept [-timeout duration] [-progress]
+  [-param name=value]... [-out dir] [-animate]
+`
+
+// the default answers manifests, relative to the root of the repo.
+const (
+	_inputAnswers  = "answers.txt"
+	_sampleAnswers = "answers_sample.txt"
+)
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, _usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+
+	var err error
+	switch cmd := os.Args[1]; cmd {
+	case "run":
+		err = run(ctx, os.Args[2:], os.Stdout, os.Stderr)
+
+	case "verify":
+		err = verify(ctx, os.Args[2:], os.Stdout, os.Stderr)
+
+	default:
+		stop()
+		fmt.Fprintf(os.Stderr, "unknown command %q\n%s", cmd, _usage)
+		os.Exit(2)
+	}
+
+	stop()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run parses the arguments for the run command, and then runs each of the
+// selected solvers, writing the results to w and any diagnostics to errw.
+func run(ctx context.Context, args []string, w, errw io.Writer) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(errw)
+	format := fs.String("format", "text", "the output format: text, json or csv")
+
+	cfg, err := parseConfig(fs, args)
+	if err != nil {
+		return err
+	}
+
+	out, err := newResultWriter(*format, w)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, day := range cfg.days {
+		if err := runDay(ctx, day, cfg, out, errw); err != nil {
+			fmt.Fprintf(errw, "day %2d: %s\n", day, err)
+			failed++
+		}
+	}
+
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d days failed", failed, len(cfg.days))
+	}
+	return nil
+}
+
+// verify parses the arguments for the verify command, and then runs each of
+// the selected solvers, comparing the answers to those in an answers manifest.
+// It writes pass, fail or unknown for each part to w.
+func verify(ctx context.Context, args []string, w, errw io.Writer) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	fs.SetOutput(errw)
+	path := fs.String("answers", "", "the answers manifest (default "+
+		_inputAnswers+", or "+_sampleAnswers+" with -sample)")
+
+	cfg, err := parseConfig(fs, args)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *path != "":
+	case cfg.src.sample:
+		*path = _sampleAnswers
+	case cfg.src.path != "":
+		return errors.New("-input requires -answers")
+	default:
+		*path = _inputAnswers
+	}
+
+	want, err := loadAnswers(*path)
+	if err != nil {
+		return err
+	}
+
+	var tally verifyTally
+	for _, day := range cfg.days {
+		if err := verifyDay(ctx, day, cfg, want, w, errw, &tally); err != nil {
+			fmt.Fprintf(errw, "day %2d: %s\n", day, err)
+			tally.errs++
+		}
+	}
+
+	fmt.Fprintf(w, "%d passed, %d failed, %d unknown\n", tally.pass, tally.fail, tally.unknown)
+	switch {
+	case tally.fail > 0:
+		return fmt.Errorf("%d failed", tally.fail)
+	case tally.errs > 0:
+		return fmt.Errorf("%d days could not be verified", tally.errs)
+	}
+	return nil
+}
+
+// config holds the options that are shared by the run and verify commands.
+type config struct {
+	days     []int
+	part     int // zero for all parts
+	src      source
+	timeout  time.Duration // zero for no limit
+	progress bool          // report the progress of each solver
+	params   paramFlag     // settings for the solvers, given with -param
+	outDir   string        // where solvers save extra files; empty for none
+	animate  bool          // have solvers animate their work on stderr
+}
+
+// parseConfig adds the shared flags to fs, and then parses args.
+func parseConfig(fs *flag.FlagSet, args []string) (config, error) {
+	cfg := config{src: source{stdin: os.Stdin}}
+	fs.IntVar(&cfg.part, "part", 0, "only run the given part (1 or 2)")
+	fs.StringVar(&cfg.src.path, "input", "", "read the input from this file, or - for stdin")
+	fs.BoolVar(&cfg.src.sample, "sample", false, "use the example input from the puzzle description")
+	fs.DurationVar(&cfg.timeout, "timeout", 0, "stop each part after this long (0 for no limit)")
+	fs.BoolVar(&cfg.progress, "progress", false, "report the progress of each solver to stderr")
+	fs.Var(&cfg.params, "param", "set a param for the solvers, as name=value (may be repeated)")
+	fs.StringVar(&cfg.outDir, "out", "", "save any images or logs from the solvers in this directory")
+	fs.BoolVar(&cfg.animate, "animate", false, "animate the solvers that can be animated, on stderr")
+
+	pos, err := parseInterleaved(fs, args)
+	if err != nil {
+		return config{}, err
+	}
+	if cfg.part < 0 || cfg.part > 2 {
+		return config{}, fmt.Errorf("invalid part: %d", cfg.part)
+	}
+	if cfg.timeout < 0 {
+		return config{}, fmt.Errorf("invalid timeout: %s", cfg.timeout)
+	}
+
+	cfg.days, err = parseDays(pos)
+	if err != nil {
+		return config{}, err
+	}
+	if cfg.src.path != "" && cfg.src.sample {
+		return config{}, errors.New("-input and -sample cannot be used together")
+	}
+	if cfg.src.path != "" && len(cfg.days) != 1 {
+		return config{}, errors.New("-input can only be used with a single day")
+	}
+	if cfg.outDir != "" {
+		if err := os.MkdirAll(cfg.outDir, 0o755); err != nil {
+			return config{}, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// paramFlag collects the name=value pairs given with -param.
+type paramFlag puzzle.Params
+
+// String implements flag.Value.
+func (f paramFlag) String() string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=%d", name, f[name])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// Set implements flag.Value.
+func (f *paramFlag) Set(s string) error {
+	name, text, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("want name=value ; got %q", s)
+	}
+	val, err := strconv.Atoi(text)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", name, err)
+	}
+	if *f == nil {
+		*f = make(paramFlag)
+	}
+	(*f)[name] = val
+	return nil
+}
+
+// runPart runs the solver for one part of a day's puzzle, applying the
+// options from cfg.  Progress reports and animations go to errw.
+func (cfg config) runPart(ctx context.Context, day, part int, data []byte, errw io.Writer) (puzzle.Result, error) {
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+		defer cancel()
+	}
+	if cfg.progress {
+		ctx = progress.WithReporter(ctx, newProgressPrinter(errw, day, part, time.Second))
+	}
+	if cfg.src.sample {
+		ctx = puzzle.WithParams(ctx, puzzle.SampleParams(day))
+	}
+	if len(cfg.params) > 0 {
+		ctx = puzzle.WithParams(ctx, puzzle.Params(cfg.params))
+	}
+	if cfg.outDir != "" {
+		ctx = puzzle.WithOutputDir(ctx, cfg.outDir)
+	}
+	if cfg.animate {
+		ctx = puzzle.WithAnimation(ctx, errw)
+	}
+	return puzzle.Run(ctx, day, part, data)
+}
+
+// runDay runs the solvers for one day using the input and options from cfg,
+// and writes the results to out.  Parts that have not been solved are noted
+// on errw.
+func runDay(ctx context.Context, day int, cfg config, out resultWriter, errw io.Writer) error {
+	part := cfg.part
+	data, err := cfg.src.read(day)
+	if err != nil {
+		return err
+	}
+
+	for p := 1; p <= puzzle.Parts(day); p++ {
+		if part != 0 && p != part {
+			continue
+		}
+
+		res, err := cfg.runPart(ctx, day, p, data, errw)
+		if errors.Is(err, puzzle.ErrNotSolved) {
+			fmt.Fprintf(errw, "day %2d part %d: not solved\n", day, p)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("part %d: %w", p, err)
+		}
+
+		if err := out.Write(res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyTally counts the outcomes of the verify command, one for each part.
+// Days whose input could not be read are counted in errs.
+type verifyTally struct{ pass, fail, unknown, errs int }
+
+// verifyDay runs the solvers for one day using the input and options from
+// cfg, and writes whether each answer matches the expected one to w.  A part
+// whose solver returns an error fails, and the parts of a day without an
+// input are unknown.  It only returns an error if the input can't be read.
+func verifyDay(ctx context.Context, day int, cfg config, want answers, w, errw io.Writer, tally *verifyTally) error {
+	data, err := cfg.src.read(day)
+	missing := errors.Is(err, errNoSample) ||
+		cfg.src.path == "" && errors.Is(err, fs.ErrNotExist)
+	if err != nil && !missing {
+		return err
+	}
+
+	for p := 1; p <= puzzle.Parts(day); p++ {
+		if cfg.part != 0 && p != cfg.part {
+			continue
+		}
+		if _, ok := puzzle.Lookup(day, p); !ok {
+			continue
+		}
+
+		if missing {
+			fmt.Fprintf(w, "day %2d part %d: unknown (%s)\n", day, p, noInputReason(err))
+			tally.unknown++
+			continue
+		}
+
+		res, err := cfg.runPart(ctx, day, p, data, errw)
+		if err != nil {
+			fmt.Fprintf(w, "day %2d part %d: fail (%s)\n", day, p, err)
+			tally.fail++
+			continue
+		}
+
+		expected, ok := want.want(day, p)
+		switch {
+		case !ok:
+			fmt.Fprintf(w, "day %2d part %d: unknown (got %s)\n", day, p, res.Answer)
+			tally.unknown++
+		case res.Answer == expected:
+			fmt.Fprintf(w, "day %2d part %d: pass\n", day, p)
+			tally.pass++
+		default:
+			fmt.Fprintf(w, "day %2d part %d: fail (got %s, want %s)\n", day, p, res.Answer, expected)
+			tally.fail++
+		}
+	}
+
+	return nil
+}
+
+// noInputReason describes why there is no input for a day, given the error
+// from reading it.
+func noInputReason(err error) string {
+	if errors.Is(err, errNoSample) {
+		return err.Error()
+	}
+	return "no input file"
+}
+
+// errNoSample is returned when a day does not have an example input.
+var errNoSample = errors.New("no sample input")
+
+// source describes where to read the input for each day.
+type source struct {
+	path   string    // the input file; empty for the default, or "-" for stdin
+	sample bool      // use the example input instead of a file
+	stdin  io.Reader // used when the path is "-"
+}
+
+// read the input for the given day.
+func (src source) read(day int) ([]byte, error) {
+	switch {
+	case src.sample:
+		s, ok := puzzle.Sample(day)
+		if !ok {
+			return nil, errNoSample
+		}
+		return []byte(s), nil
+
+	case src.path == "-":
+		return io.ReadAll(src.stdin)
+
+	case src.path != "":
+		return os.ReadFile(src.path)
+
+	default:
+		return os.ReadFile(fmt.Sprintf("cmd/day%02d/input.txt", day))
+	}
+}
+
+// parseDays converts the given arguments into a list of days to run.
+// The argument "all" selects every registered day.
+func parseDays(args []string) ([]int, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no days given")
+	}
+
+	days := make([]int, 0, len(args))
+	for _, arg := range args {
+		if arg == "all" {
+			return puzzle.Days(), nil
+		}
+
+		day, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid day %q: %w", arg, err)
+		}
+		if puzzle.Parts(day) == 0 {
+			return nil, fmt.Errorf("day %d has not been solved", day)
+		}
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+// parseInterleaved parses the flags in args using the given flag set,
+// allowing the flags to appear before, between or after the positional
+// arguments.  It returns the positional arguments.
+func parseInterleaved(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return pos, nil
+		}
+		pos = append(pos, args[0])
+		args = args[1:]
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterleaved(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		in       []string
+		wantPos  []string
+		wantPart int
+	}{
+		{
+			name:    "no flags",
+			in:      []string{"12"},
+			wantPos: []string{"12"},
+		},
+		{
+			name:     "flag before the days",
+			in:       []string{"-part", "2", "12", "14"},
+			wantPos:  []string{"12", "14"},
+			wantPart: 2,
+		},
+		{
+			name:     "flag after the days",
+			in:       []string{"14", "--part", "2"},
+			wantPos:  []string{"14"},
+			wantPart: 2,
+		},
+		{
+			name:     "flag between the days",
+			in:       []string{"12", "-part=1", "14"},
+			wantPos:  []string{"12", "14"},
+			wantPart: 1,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			part := fs.Int("part", 0, "")
+
+			pos, err := parseInterleaved(fs, tc.in)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantPos, pos)
+			assert.Equal(t, tc.wantPart, *part)
+		})
+	}
+}
+
+func TestParseDays(t *testing.T) {
+	t.Parallel()
+
+	got, err := parseDays([]string{"14", "12"})
+	require.NoError(t, err)
+	assert.Equal(t, []int{14, 12}, got)
+
+	all, err := parseDays([]string{"all"})
+	require.NoError(t, err)
+	assert.Contains(t, all, 1)
+	assert.Contains(t, all, 25)
+	assert.IsIncreasing(t, all)
+
+	_, err = parseDays([]string{"4"})
+	assert.Error(t, err, "day 4 was solved in SQL, not Go")
+
+	_, err = parseDays([]string{"twelve"})
+	assert.Error(t, err)
+
+	_, err = parseDays(nil)
+	assert.Error(t, err)
+}
+
+func TestSource_read(t *testing.T) {
+	t.Parallel()
+
+	stdin := strings.NewReader("from stdin")
+
+	tt := []struct {
+		name    string
+		src     source
+		day     int
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "stdin",
+			src:  source{path: "-", stdin: stdin},
+			day:  1,
+			want: "from stdin",
+		},
+		{
+			name: "sample",
+			src:  source{sample: true},
+			day:  2,
+			want: "A Y\nB X\nC Z\n",
+		},
+		{
+			name:    "a day without a sample",
+			src:     source{sample: true},
+			day:     99,
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			src:     source{path: "no-such-file.txt"},
+			day:     1,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.src.read(tc.day)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestParseConfig_params(t *testing.T) {
+	t.Parallel()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg, err := parseConfig(fs, []string{"16", "-param", "beam=0", "19", "-param=beam=8", "-param", "row=3"})
+	require.NoError(t, err)
+	assert.Equal(t, []int{16, 19}, cfg.days)
+	assert.Equal(t, paramFlag{"beam": 8, "row": 3}, cfg.params)
+	assert.Equal(t, "beam=8,row=3", cfg.params.String())
+
+	for _, bad := range []string{"beam", "=1", "beam=wide"} {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		_, err := parseConfig(fs, []string{"16", "-param", bad})
+		assert.Error(t, err, "-param %s", bad)
+	}
+}
+
+func TestConfig_runPart(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(mustSample(t, 12))
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config{timeout: time.Nanosecond}
+		_, err := cfg.runPart(context.Background(), 12, 1, data, io.Discard)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("progress", func(t *testing.T) {
+		t.Parallel()
+
+		var errw bytes.Buffer
+		cfg := config{progress: true}
+		res, err := cfg.runPart(context.Background(), 12, 1, data, &errw)
+		require.NoError(t, err)
+		assert.Equal(t, "31", res.Answer)
+		assert.Contains(t, errw.String(), "day 12 part 1: expanded ")
+	})
+
+	t.Run("sample params", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config{src: source{sample: true}}
+		res, err := cfg.runPart(context.Background(), 15, 1, []byte(mustSample(t, 15)), io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, "26", res.Answer)
+
+		// a param given to the runner takes precedence over the sample's:
+		cfg.params = paramFlag{"row": 9}
+		res, err = cfg.runPart(context.Background(), 15, 1, []byte(mustSample(t, 15)), io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, "25", res.Answer)
+	})
+
+	t.Run("out", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		cfg := config{outDir: dir}
+		res, err := cfg.runPart(context.Background(), 9, 1, []byte(mustSample(t, 9)), io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, "13", res.Answer)
+
+		files, err := filepath.Glob(filepath.Join(dir, "day09_part1_*.png"))
+		require.NoError(t, err)
+		assert.NotEmpty(t, files)
+	})
+}
+
+func mustSample(t *testing.T, day int) string {
+	t.Helper()
+	s, ok := puzzle.Sample(day)
+	require.True(t, ok, "day %d has no sample", day)
+	return s
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nealmcc/aoc2022/pkg/progress"
+)
+
+// progressPrinter is a progress.Reporter that writes each report to w,
+// labelled with the day and part.  Reports that arrive within interval of
+// the last one written are dropped.
+type progressPrinter struct {
+	w         io.Writer
+	day, part int
+	interval  time.Duration
+	last      time.Time
+}
+
+// newProgressPrinter creates a progressPrinter for the given day and part.
+func newProgressPrinter(w io.Writer, day, part int, interval time.Duration) *progressPrinter {
+	return &progressPrinter{w: w, day: day, part: part, interval: interval}
+}
+
+// Report implements progress.Reporter.
+func (p *progressPrinter) Report(r progress.Report) {
+	now := time.Now()
+	if !p.last.IsZero() && now.Sub(p.last) < p.interval {
+		return
+	}
+	p.last = now
+
+	stage := ""
+	if r.Stage != "" {
+		stage = r.Stage + ": "
+	}
+	fmt.Fprintf(p.w, "day %2d part %d: %sexpanded %d, frontier %d, best %d\n",
+		p.day, p.part, stage, r.Expanded, r.Frontier, r.Best)
+}
@@ -1,45 +1,70 @@
-package main
+package day01
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"sort"
 	"strconv"
-	"time"
+
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 )
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
+func init() {
+	puzzle.RegisterSample(1, _sample)
+	puzzle.Register(1,
+		func(_ context.Context, r io.Reader) (any, error) {
+			elves, err := readElves(r)
+			if err != nil {
+				return nil, fmt.Errorf("read elves: %w", err)
+			}
+			return part1(elves), nil
+		},
+		func(_ context.Context, r io.Reader) (any, error) {
+			elves, err := readElves(r)
+			if err != nil {
+				return nil, fmt.Errorf("read elves: %w", err)
+			}
+			return part2(elves), nil
+		})
+}
 
-	start := time.Now()
-	elves, err := readElves(file)
-	if err != nil {
-		log.Fatalf("read elves: %s", err)
-	}
+// _sample is the example input from the puzzle description.
+const _sample = `1000
+2000
+3000
 
-	sort.Slice(elves, func(i, j int) bool {
-		return elves[i].calories > elves[j].calories
-	})
+4000
 
-	e1 := elves[0]
-	e2 := elves[1]
-	e3 := elves[2]
+5000
+6000
 
-	p1 := e1.calories
-	p2 := e1.calories + e2.calories + e3.calories
+7000
+8000
+9000
 
-	end := time.Now()
+10000
+`
 
-	fmt.Println("part 1", p1)
-	fmt.Println("part 2:", p2)
-	fmt.Println("time taken:", end.Sub(start))
+// part1 finds the elf carrying the most calories, and returns their total.
+func part1(elves []elf) int {
+	byCalories(elves)
+	return elves[0].calories
+}
+
+// part2 finds the top three elves carrying the most calories, and returns
+// their combined total.
+func part2(elves []elf) int {
+	byCalories(elves)
+	return elves[0].calories + elves[1].calories + elves[2].calories
+}
+
+// byCalories sorts the given elves with the most calories first.
+func byCalories(elves []elf) {
+	sort.Slice(elves, func(i, j int) bool {
+		return elves[i].calories > elves[j].calories
+	})
 }
 
 type elf struct {
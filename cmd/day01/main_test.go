@@ -1,4 +1,4 @@
-package main
+package day01
 
 import (
 	"bufio"
@@ -9,22 +9,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var _sample = `1000
-2000
-3000
-
-4000
-
-5000
-6000
-
-7000
-8000
-9000
-
-10000
-`
-
 func TestReadElves(t *testing.T) {
 	t.Parallel()
 
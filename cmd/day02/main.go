@@ -1,41 +1,27 @@
-package main
+package day02
 
 import (
 	"bufio"
+	"context"
 	"errors"
-	"fmt"
 	"io"
-	"log"
-	"os"
-	"time"
-)
-
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	start := time.Now()
-	p1, err := addScores(file, part1)
-	if err != nil {
-		log.Fatalf("part1: %s", err)
-	}
 
-	middle := time.Now()
-	file.Seek(0, 0)
-
-	p2, err := addScores(file, part2)
-	if err != nil {
-		log.Fatalf("part2: %s", err)
-	}
-	end := time.Now()
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+)
 
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+func init() {
+	puzzle.RegisterSample(2, _sample)
+	puzzle.Register(2,
+		func(_ context.Context, r io.Reader) (any, error) { return addScores(r, part1) },
+		func(_ context.Context, r io.Reader) (any, error) { return addScores(r, part2) })
 }
 
+// _sample is the example input from the puzzle description.
+const _sample = `A Y
+B X
+C Z
+`
+
 func addScores(r io.Reader, score func(row string) (int, error)) (int, error) {
 	var sum int
 	s := bufio.NewScanner(r)
@@ -1,42 +1,31 @@
-package main
+package day03
 
 import (
 	"bufio"
-	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"log"
-	"os"
-	"time"
-)
-
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	start := time.Now()
-	p1, err := part1(file)
-	if err != nil {
-		log.Fatalf("part1: %d", err)
-	}
 
-	middle := time.Now()
-	file.Seek(0, 0)
-
-	p2, err := part2(file)
-	if err != nil {
-		log.Fatalf("part2: %s", err)
-	}
-	end := time.Now()
+	"github.com/nealmcc/aoc2022/pkg/collection"
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+)
 
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+func init() {
+	puzzle.RegisterSample(3, _sample)
+	puzzle.Register(3,
+		func(_ context.Context, r io.Reader) (any, error) { return part1(r) },
+		func(_ context.Context, r io.Reader) (any, error) { return part2(r) })
 }
 
+// _sample is the example input from the puzzle description.
+const _sample = `vJrwpWtwJgWrhcsFMMfFFhFp
+jqHRNqRjqzjGDLGLrsFMfFZSrLrFZsSL
+PmmdzqPrVvPwwTWBwg
+wMqvLMZHhHMvwLHjbvcjnnSBnvTQFn
+ttgJtRGJQctTZtZT
+CrZsJsPPZsGzwwsLwLmpwMDw`
+
 // part1 determines:
 // for each bag, which item is in both of that bag's compartments?
 func part1(r io.Reader) (int, error) {
@@ -89,9 +78,17 @@ func part2(r io.Reader) (int, error) {
 // a bag is a collection of items.
 type bag []byte
 
-// contains determines if this bag contains the given item.
-func (b bag) contains(x item) bool {
-	return bytes.Contains(b, []byte{byte(x)})
+// items returns the set of items in this bag.  Each item is stored as its
+// offset from 'A', so that all of the letters fit in a BitSet64.
+func (b bag) items() (collection.BitSet64, error) {
+	var set collection.BitSet64
+	for _, x := range b {
+		if !item(x).isValid() {
+			return 0, fmt.Errorf("%q is not an item", x)
+		}
+		set.Add(int(x - 'A'))
+	}
+	return set, nil
 }
 
 // left returns the bag's left compartment.
@@ -107,6 +104,11 @@ func (b bag) right() bag {
 // an item is an uppercase or lowercase letter
 type item byte
 
+// isValid checks to see if x is a letter.
+func (x item) isValid() bool {
+	return 'a' <= x && x <= 'z' || 'A' <= x && x <= 'Z'
+}
+
 // priority gets the priority of this item.
 func (x item) priority() int {
 	// note that although 'a' follows 'Z',
@@ -119,22 +121,22 @@ func (x item) priority() int {
 
 // findCommonItem determines which item is in all of the given bags.
 func findCommonItem(bags ...bag) (item, error) {
-	var x item
-	for x = 'A'; x <= 'z'; x++ {
-		if allContain(x, bags...) {
-			return x, nil
-		}
+	if len(bags) == 0 {
+		return 0, errors.New("no bags given")
 	}
 
-	return 0, errors.New("no duplicate found")
-}
-
-// allContain determines if all the given bags contain the given item.
-func allContain(x item, bags ...bag) bool {
-	for _, b := range bags {
-		if !b.contains(x) {
-			return false
+	sets := make([]collection.BitSet64, len(bags))
+	for i, b := range bags {
+		set, err := b.items()
+		if err != nil {
+			return 0, err
 		}
+		sets[i] = set
+	}
+
+	x, ok := sets[0].Intersect(sets[1:]...).Min()
+	if !ok {
+		return 0, errors.New("no duplicate found")
 	}
-	return true
+	return item('A' + x), nil
 }
@@ -1,17 +1,10 @@
-package main
+package day03
 
 import (
 	"strings"
 	"testing"
 )
 
-var _sample = `vJrwpWtwJgWrhcsFMMfFFhFp
-jqHRNqRjqzjGDLGLrsFMfFZSrLrFZsSL
-PmmdzqPrVvPwwTWBwg
-wMqvLMZHhHMvwLHjbvcjnnSBnvTQFn
-ttgJtRGJQctTZtZT
-CrZsJsPPZsGzwwsLwLmpwMDw`
-
 func TestPart1(t *testing.T) {
 	t.Parallel()
 
@@ -145,3 +138,14 @@ func TestFindCommonItem(t *testing.T) {
 		})
 	}
 }
+
+func TestFindCommonItem_notALetter(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []bag{bag("ab1a"), bag("ab[a"), bag("ab~a")} {
+		if got, err := findCommonItem(in, bag("a")); err == nil {
+			t.Logf("findCommonItem(%q) = %c ; want an error", in, got)
+			t.Fail()
+		}
+	}
+}
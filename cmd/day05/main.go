@@ -1,570 +1,169 @@
-package main
+package day05
 
 import (
+	"bufio"
+	"context"
 	"fmt"
-	"time"
+	"io"
+	"strings"
+
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+)
+
+func init() {
+	puzzle.RegisterSample(5, _sample)
+	puzzle.Register(5,
+		func(_ context.Context, r io.Reader) (any, error) { return solve(r, CrateMover9000) },
+		func(_ context.Context, r io.Reader) (any, error) { return solve(r, CrateMover9001) })
+}
+
+// _sample is the example input from the puzzle description.
+const _sample = `    [D]
+[N] [C]
+[Z] [M] [P]
+ 1   2   3
+
+move 1 from 2 to 1
+move 3 from 1 to 3
+move 2 from 2 to 1
+move 1 from 1 to 2
+`
+
+// stack is a stack of crates, from the bottom to the top.
+type stack []byte
+
+// step is one step of the rearrangement procedure.
+type step struct {
+	qty, from, to int
+}
+
+// Mover is a model of crane, which decides how crates are moved.
+type Mover int
+
+const (
+	CrateMover9000 Mover = iota // moves one crate at a time
+	CrateMover9001              // moves several crates at once
 )
 
-func main() {
-	// file, err := os.Open("input.txt")
-	// if err != nil {
-	// 	log.Fatal(err)
-	// }
-	// defer file.Close()
+// solve reads the drawing of the stacks and the procedure, and then returns
+// the crates that end up on top of each stack after the given crane moves them.
+func solve(r io.Reader, m Mover) (string, error) {
+	stacks, steps, err := read(r)
+	if err != nil {
+		return "", err
+	}
+
+	for i, s := range steps {
+		if err := m.move(stacks, s); err != nil {
+			return "", fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+
+	return tops(stacks), nil
+}
 
-	crates := []crate{
-		[]byte{}, // the input is 1-based
-		[]byte("WRF"),
-		[]byte("THMCDVWP"),
-		[]byte("PMZNL"),
-		[]byte("JCHR"),
-		[]byte("CPGHQTB"),
-		[]byte("GCWLFZ"),
-		[]byte("WVLQZJGC"),
-		[]byte("PNRFWTVC"),
-		[]byte("JWHGRSV"),
+// move performs one step of the procedure.
+func (m Mover) move(stacks []stack, s step) error {
+	if s.from < 1 || s.from >= len(stacks) || s.to < 1 || s.to >= len(stacks) {
+		return fmt.Errorf("no such stack: move %d from %d to %d", s.qty, s.from, s.to)
+	}
+	if s.qty > len(stacks[s.from]) {
+		return fmt.Errorf("stack %d only has %d crates; cannot move %d",
+			s.from, len(stacks[s.from]), s.qty)
 	}
 
-	start := time.Now()
-	// p1 := part1(crates)
-	p2 := part2(crates)
+	// copy the crates being moved, since appending them to the same stack
+	// they came from would overwrite them:
+	split := len(stacks[s.from]) - s.qty
+	top := append(stack(nil), stacks[s.from][split:]...)
+	stacks[s.from] = stacks[s.from][:split]
 
-	middle := time.Now()
+	switch m {
+	case CrateMover9000:
+		for i := len(top) - 1; i >= 0; i-- {
+			stacks[s.to] = append(stacks[s.to], top[i])
+		}
+	case CrateMover9001:
+		stacks[s.to] = append(stacks[s.to], top...)
+	default:
+		return fmt.Errorf("unknown crane %d", m)
+	}
 
-	// p2, err := part2(file)
-	// if err != nil {
-	// 	log.Fatalf("part2: %s", err)
-	// }
-	// end := time.Now()
+	return nil
+}
 
-	fmt.Printf("part 2: %s in %s\n", p2, middle.Sub(start))
-	// fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+// tops returns the crate on top of each stack.  Empty stacks are skipped.
+func tops(stacks []stack) string {
+	var sb strings.Builder
+	for _, s := range stacks {
+		if len(s) > 0 {
+			sb.WriteByte(s[len(s)-1])
+		}
+	}
+	return sb.String()
 }
 
-type crate []byte
+// read parses the drawing of the starting stacks, followed by the steps of
+// the procedure.  The stacks are 1-based, so stacks[0] is always empty.
+func read(r io.Reader) ([]stack, []step, error) {
+	s := bufio.NewScanner(r)
+
+	var drawing []string
+	for s.Scan() && s.Text() != "" {
+		drawing = append(drawing, s.Text())
+	}
+
+	stacks, err := parseDrawing(drawing)
+	if err != nil {
+		return nil, nil, err
+	}
 
-// part1 moves crates usint the rules from part 1.
-func part2(crates []crate) []byte {
-	move(crates, 2, 4, 9)
-	move(crates, 5, 2, 9)
-	move(crates, 1, 5, 1)
-	move(crates, 3, 1, 4)
-	move(crates, 2, 4, 6)
-	move(crates, 7, 6, 9)
-	move(crates, 5, 3, 9)
-	move(crates, 1, 8, 6)
-	move(crates, 3, 2, 3)
-	move(crates, 12, 9, 3)
-	move(crates, 4, 9, 7)
-	move(crates, 15, 3, 9)
-	move(crates, 1, 1, 5)
-	move(crates, 2, 6, 5)
-	move(crates, 18, 9, 1)
-	move(crates, 6, 8, 1)
-	move(crates, 1, 8, 7)
-	move(crates, 5, 7, 2)
-	move(crates, 6, 1, 2)
-	move(crates, 7, 9, 6)
-	move(crates, 6, 1, 3)
-	move(crates, 5, 3, 9)
-	move(crates, 3, 9, 1)
-	move(crates, 1, 7, 9)
-	move(crates, 4, 2, 1)
-	move(crates, 5, 6, 3)
-	move(crates, 1, 3, 4)
-	move(crates, 1, 5, 9)
-	move(crates, 2, 9, 6)
-	move(crates, 5, 5, 9)
-	move(crates, 10, 1, 8)
-	move(crates, 4, 3, 8)
-	move(crates, 3, 4, 9)
-	move(crates, 4, 6, 9)
-	move(crates, 14, 8, 6)
-	move(crates, 1, 3, 8)
-	move(crates, 14, 9, 4)
-	move(crates, 6, 1, 6)
-	move(crates, 1, 8, 2)
-	move(crates, 3, 5, 8)
-	move(crates, 1, 8, 9)
-	move(crates, 1, 8, 1)
-	move(crates, 5, 4, 9)
-	move(crates, 1, 8, 4)
-	move(crates, 3, 9, 4)
-	move(crates, 3, 7, 5)
-	move(crates, 7, 6, 3)
-	move(crates, 7, 4, 1)
-	move(crates, 3, 9, 1)
-	move(crates, 7, 2, 3)
-	move(crates, 1, 4, 8)
-	move(crates, 8, 6, 2)
-	move(crates, 2, 7, 4)
-	move(crates, 1, 7, 4)
-	move(crates, 1, 7, 9)
-	move(crates, 1, 5, 9)
-	move(crates, 1, 9, 4)
-	move(crates, 1, 4, 2)
-	move(crates, 8, 4, 9)
-	move(crates, 1, 4, 2)
-	move(crates, 5, 9, 4)
-	move(crates, 2, 6, 9)
-	move(crates, 1, 6, 9)
-	move(crates, 1, 8, 1)
-	move(crates, 13, 3, 2)
-	move(crates, 1, 3, 9)
-	move(crates, 2, 6, 8)
-	move(crates, 1, 8, 1)
-	move(crates, 14, 1, 7)
-	move(crates, 4, 2, 1)
-	move(crates, 2, 9, 5)
-	move(crates, 3, 9, 7)
-	move(crates, 1, 8, 2)
-	move(crates, 4, 1, 5)
-	move(crates, 1, 4, 7)
-	move(crates, 3, 9, 1)
-	move(crates, 7, 7, 4)
-	move(crates, 14, 2, 8)
-	move(crates, 3, 1, 7)
-	move(crates, 3, 5, 4)
-	move(crates, 2, 1, 9)
-	move(crates, 11, 8, 9)
-	move(crates, 3, 7, 8)
-	move(crates, 3, 8, 6)
-	move(crates, 6, 4, 3)
-	move(crates, 2, 6, 8)
-	move(crates, 8, 4, 3)
-	move(crates, 3, 8, 7)
-	move(crates, 2, 8, 2)
-	move(crates, 2, 3, 9)
-	move(crates, 1, 6, 8)
-	move(crates, 5, 2, 7)
-	move(crates, 10, 9, 7)
-	move(crates, 1, 8, 5)
-	move(crates, 3, 5, 2)
-	move(crates, 6, 7, 5)
-	move(crates, 19, 7, 3)
-	move(crates, 9, 5, 9)
-	move(crates, 6, 2, 6)
-	move(crates, 2, 7, 3)
-	move(crates, 29, 3, 8)
-	move(crates, 2, 7, 9)
-	move(crates, 5, 8, 1)
-	move(crates, 12, 9, 6)
-	move(crates, 1, 3, 8)
-	move(crates, 1, 2, 7)
-	move(crates, 1, 3, 1)
-	move(crates, 10, 6, 1)
-	move(crates, 1, 6, 7)
-	move(crates, 9, 1, 9)
-	move(crates, 2, 1, 2)
-	move(crates, 12, 9, 4)
-	move(crates, 7, 6, 3)
-	move(crates, 8, 3, 7)
-	move(crates, 5, 7, 6)
-	move(crates, 19, 8, 3)
-	move(crates, 10, 4, 6)
-	move(crates, 1, 4, 6)
-	move(crates, 6, 8, 6)
-	move(crates, 1, 4, 2)
-	move(crates, 6, 6, 3)
-	move(crates, 3, 2, 7)
-	move(crates, 13, 6, 3)
-	move(crates, 1, 9, 1)
-	move(crates, 6, 1, 8)
-	move(crates, 1, 6, 5)
-	move(crates, 1, 5, 4)
-	move(crates, 3, 7, 1)
-	move(crates, 2, 1, 3)
-	move(crates, 11, 3, 8)
-	move(crates, 1, 4, 3)
-	move(crates, 3, 8, 4)
-	move(crates, 1, 7, 5)
-	move(crates, 3, 8, 9)
-	move(crates, 2, 9, 2)
-	move(crates, 7, 8, 3)
-	move(crates, 1, 7, 9)
-	move(crates, 1, 1, 4)
-	move(crates, 32, 3, 4)
-	move(crates, 1, 5, 9)
-	move(crates, 2, 8, 3)
-	move(crates, 2, 6, 4)
-	move(crates, 1, 9, 4)
-	move(crates, 1, 9, 2)
-	move(crates, 3, 3, 1)
-	move(crates, 1, 8, 6)
-	move(crates, 1, 6, 2)
-	move(crates, 1, 9, 3)
-	move(crates, 1, 1, 7)
-	move(crates, 1, 8, 7)
-	move(crates, 2, 3, 8)
-	move(crates, 1, 8, 4)
-	move(crates, 1, 1, 2)
-	move(crates, 2, 4, 8)
-	move(crates, 1, 1, 8)
-	move(crates, 26, 4, 6)
-	move(crates, 3, 8, 5)
-	move(crates, 3, 7, 6)
-	move(crates, 7, 6, 3)
-	move(crates, 18, 6, 8)
-	move(crates, 16, 8, 9)
-	move(crates, 1, 5, 1)
-	move(crates, 2, 8, 3)
-	move(crates, 3, 9, 8)
-	move(crates, 3, 6, 4)
-	move(crates, 2, 5, 4)
-	move(crates, 1, 6, 4)
-	move(crates, 2, 7, 2)
-	move(crates, 2, 3, 9)
-	move(crates, 4, 8, 3)
-	move(crates, 1, 1, 2)
-	move(crates, 6, 9, 7)
-	move(crates, 2, 2, 5)
-	move(crates, 12, 3, 1)
-	move(crates, 9, 9, 2)
-	move(crates, 10, 1, 3)
-	move(crates, 2, 5, 9)
-	move(crates, 8, 4, 7)
-	move(crates, 13, 7, 6)
-	move(crates, 6, 6, 5)
-	move(crates, 4, 5, 3)
-	move(crates, 2, 5, 4)
-	move(crates, 8, 4, 3)
-	move(crates, 1, 7, 2)
-	move(crates, 15, 2, 7)
-	move(crates, 8, 3, 7)
-	move(crates, 1, 1, 6)
-	move(crates, 7, 7, 1)
-	move(crates, 5, 1, 6)
-	move(crates, 7, 3, 2)
-	move(crates, 3, 1, 6)
-	move(crates, 12, 7, 9)
-	move(crates, 12, 9, 8)
-	move(crates, 1, 7, 1)
-	move(crates, 2, 9, 5)
-	move(crates, 1, 1, 9)
-	move(crates, 4, 4, 2)
-	move(crates, 4, 8, 4)
-	move(crates, 2, 7, 2)
-	move(crates, 4, 6, 5)
-	move(crates, 4, 8, 9)
-	move(crates, 1, 8, 4)
-	move(crates, 5, 5, 3)
-	move(crates, 5, 2, 4)
-	move(crates, 5, 9, 5)
-	move(crates, 1, 3, 6)
-	move(crates, 1, 7, 8)
-	move(crates, 12, 3, 9)
-	move(crates, 4, 2, 6)
-	move(crates, 7, 4, 9)
-	move(crates, 13, 6, 4)
-	move(crates, 3, 6, 9)
-	move(crates, 4, 4, 2)
-	move(crates, 1, 3, 4)
-	move(crates, 21, 9, 7)
-	move(crates, 4, 2, 1)
-	move(crates, 3, 5, 4)
-	move(crates, 8, 7, 6)
-	move(crates, 2, 7, 2)
-	move(crates, 11, 4, 2)
-	move(crates, 1, 9, 7)
-	move(crates, 1, 5, 7)
-	move(crates, 1, 1, 8)
-	move(crates, 5, 2, 5)
-	move(crates, 1, 3, 5)
-	move(crates, 2, 4, 9)
-	move(crates, 3, 4, 8)
-	move(crates, 3, 1, 8)
-	move(crates, 1, 9, 6)
-	move(crates, 8, 7, 8)
-	move(crates, 9, 6, 5)
-	move(crates, 1, 9, 6)
-	move(crates, 1, 6, 4)
-	move(crates, 3, 7, 5)
-	move(crates, 1, 6, 9)
-	move(crates, 12, 5, 1)
-	move(crates, 2, 5, 8)
-	move(crates, 1, 9, 6)
-	move(crates, 2, 7, 6)
-	move(crates, 9, 1, 8)
-	move(crates, 1, 6, 9)
-	move(crates, 1, 9, 2)
-	move(crates, 1, 4, 2)
-	move(crates, 2, 6, 7)
-	move(crates, 5, 8, 3)
-	move(crates, 2, 7, 4)
-	move(crates, 16, 8, 5)
-	move(crates, 2, 3, 8)
-	move(crates, 7, 5, 1)
-	move(crates, 3, 3, 8)
-	move(crates, 7, 5, 7)
-	move(crates, 4, 5, 2)
-	move(crates, 6, 7, 9)
-	move(crates, 2, 9, 6)
-	move(crates, 2, 9, 2)
-	move(crates, 1, 6, 8)
-	move(crates, 12, 2, 6)
-	move(crates, 2, 9, 6)
-	move(crates, 1, 5, 2)
-	move(crates, 3, 5, 4)
-	move(crates, 9, 2, 6)
-	move(crates, 6, 8, 3)
-	move(crates, 1, 7, 5)
-	move(crates, 1, 6, 7)
-	move(crates, 1, 7, 8)
-	move(crates, 1, 5, 8)
-	move(crates, 5, 1, 2)
-	move(crates, 3, 4, 5)
-	move(crates, 4, 6, 8)
-	move(crates, 5, 2, 9)
-	move(crates, 5, 8, 4)
-	move(crates, 1, 1, 4)
-	move(crates, 9, 8, 4)
-	move(crates, 1, 2, 3)
-	move(crates, 3, 6, 8)
-	move(crates, 4, 9, 2)
-	move(crates, 2, 6, 4)
-	move(crates, 2, 3, 1)
-	move(crates, 4, 4, 7)
-	move(crates, 6, 4, 5)
-	move(crates, 10, 6, 8)
-	move(crates, 4, 1, 9)
-	move(crates, 4, 7, 5)
-	move(crates, 3, 3, 9)
-	move(crates, 6, 9, 8)
-	move(crates, 2, 2, 9)
-	move(crates, 8, 4, 3)
-	move(crates, 2, 2, 7)
-	move(crates, 1, 4, 9)
-	move(crates, 6, 3, 8)
-	move(crates, 2, 7, 8)
-	move(crates, 6, 5, 9)
-	move(crates, 5, 5, 6)
-	move(crates, 2, 5, 9)
-	move(crates, 7, 9, 5)
-	move(crates, 2, 1, 9)
-	move(crates, 6, 5, 8)
-	move(crates, 1, 5, 1)
-	move(crates, 2, 3, 6)
-	move(crates, 1, 3, 6)
-	move(crates, 4, 9, 5)
-	move(crates, 1, 3, 4)
-	move(crates, 1, 1, 2)
-	move(crates, 1, 2, 1)
-	move(crates, 1, 6, 8)
-	move(crates, 14, 8, 5)
-	move(crates, 6, 5, 1)
-	move(crates, 16, 8, 3)
-	move(crates, 2, 8, 2)
-	move(crates, 10, 6, 7)
-	move(crates, 1, 6, 9)
-	move(crates, 2, 2, 9)
-	move(crates, 2, 7, 3)
-	move(crates, 1, 8, 5)
-	move(crates, 3, 9, 1)
-	move(crates, 4, 9, 5)
-	move(crates, 9, 3, 8)
-	move(crates, 2, 3, 6)
-	move(crates, 5, 3, 8)
-	move(crates, 1, 4, 2)
-	move(crates, 12, 8, 4)
-	move(crates, 1, 8, 9)
-	move(crates, 4, 5, 9)
-	move(crates, 7, 7, 1)
-	move(crates, 10, 5, 2)
-	move(crates, 2, 5, 2)
-	move(crates, 1, 6, 5)
-	move(crates, 2, 5, 2)
-	move(crates, 5, 2, 6)
-	move(crates, 4, 9, 6)
-	move(crates, 6, 4, 9)
-	move(crates, 2, 3, 4)
-	move(crates, 6, 4, 7)
-	move(crates, 6, 7, 5)
-	move(crates, 10, 1, 5)
-	move(crates, 4, 1, 2)
-	move(crates, 4, 6, 3)
-	move(crates, 6, 9, 7)
-	move(crates, 2, 4, 9)
-	move(crates, 7, 7, 6)
-	move(crates, 1, 9, 7)
-	move(crates, 2, 9, 8)
-	move(crates, 2, 8, 2)
-	move(crates, 1, 2, 5)
-	move(crates, 3, 8, 4)
-	move(crates, 4, 2, 7)
-	move(crates, 3, 4, 7)
-	move(crates, 2, 3, 5)
-	move(crates, 2, 3, 2)
-	move(crates, 18, 5, 3)
-	move(crates, 6, 3, 1)
-	move(crates, 8, 3, 1)
-	move(crates, 8, 7, 9)
-	move(crates, 9, 2, 5)
-	move(crates, 3, 2, 3)
-	move(crates, 7, 3, 7)
-	move(crates, 3, 6, 4)
-	move(crates, 1, 7, 1)
-	move(crates, 7, 6, 7)
-	move(crates, 1, 2, 9)
-	move(crates, 1, 4, 2)
-	move(crates, 13, 7, 2)
-	move(crates, 10, 5, 3)
-	move(crates, 1, 2, 9)
-	move(crates, 7, 1, 5)
-	move(crates, 8, 9, 5)
-	move(crates, 1, 9, 5)
-	move(crates, 1, 9, 8)
-	move(crates, 1, 8, 2)
-	move(crates, 8, 5, 3)
-	move(crates, 18, 3, 5)
-	move(crates, 2, 4, 1)
-	move(crates, 3, 2, 5)
-	move(crates, 27, 5, 1)
-	move(crates, 17, 1, 5)
-	move(crates, 2, 2, 3)
-	move(crates, 1, 6, 5)
-	move(crates, 2, 2, 5)
-	move(crates, 1, 6, 4)
-	move(crates, 1, 6, 9)
-	move(crates, 2, 3, 5)
-	move(crates, 17, 5, 6)
-	move(crates, 1, 9, 3)
-	move(crates, 6, 2, 4)
-	move(crates, 1, 3, 2)
-	move(crates, 3, 4, 9)
-	move(crates, 1, 2, 9)
-	move(crates, 1, 4, 7)
-	move(crates, 3, 5, 2)
-	move(crates, 2, 5, 1)
-	move(crates, 1, 5, 2)
-	move(crates, 1, 7, 3)
-	move(crates, 18, 1, 4)
-	move(crates, 1, 3, 1)
-	move(crates, 5, 4, 2)
-	move(crates, 1, 5, 1)
-	move(crates, 9, 2, 7)
-	move(crates, 1, 4, 5)
-	move(crates, 1, 2, 9)
-	move(crates, 8, 6, 2)
-	move(crates, 13, 4, 2)
-	move(crates, 2, 4, 9)
-	move(crates, 1, 5, 2)
-	move(crates, 1, 6, 8)
-	move(crates, 6, 7, 5)
-	move(crates, 1, 8, 4)
-	move(crates, 1, 7, 6)
-	move(crates, 1, 6, 1)
-	move(crates, 7, 6, 5)
-	move(crates, 1, 7, 9)
-	move(crates, 6, 9, 3)
-	move(crates, 2, 9, 7)
-	move(crates, 2, 5, 7)
-	move(crates, 4, 7, 8)
-	move(crates, 4, 5, 4)
-	move(crates, 1, 6, 7)
-	move(crates, 3, 3, 8)
-	move(crates, 6, 5, 9)
-	move(crates, 2, 3, 5)
-	move(crates, 4, 4, 7)
-	move(crates, 1, 3, 1)
-	move(crates, 2, 2, 3)
-	move(crates, 6, 9, 6)
-	move(crates, 1, 7, 1)
-	move(crates, 19, 2, 4)
-	move(crates, 2, 5, 6)
-	move(crates, 2, 8, 9)
-	move(crates, 2, 1, 2)
-	move(crates, 2, 2, 5)
-	move(crates, 2, 4, 3)
-	move(crates, 4, 6, 2)
-	move(crates, 1, 7, 8)
-	move(crates, 6, 1, 8)
-	move(crates, 3, 5, 1)
-	move(crates, 5, 2, 5)
-	move(crates, 1, 6, 7)
-	move(crates, 9, 8, 1)
-	move(crates, 2, 3, 6)
-	move(crates, 4, 6, 5)
-	move(crates, 1, 6, 2)
-	move(crates, 9, 5, 2)
-	move(crates, 3, 4, 6)
-	move(crates, 12, 4, 6)
-	move(crates, 1, 9, 4)
-	move(crates, 1, 3, 1)
-	move(crates, 3, 4, 8)
-	move(crates, 1, 3, 6)
-	move(crates, 6, 6, 2)
-	move(crates, 1, 4, 5)
-	move(crates, 3, 6, 2)
-	move(crates, 4, 1, 5)
-	move(crates, 1, 5, 1)
-	move(crates, 2, 8, 9)
-	move(crates, 7, 6, 3)
-	move(crates, 1, 3, 1)
-	move(crates, 1, 8, 1)
-	move(crates, 3, 8, 9)
-	move(crates, 4, 3, 5)
-	move(crates, 3, 7, 3)
-	move(crates, 5, 3, 7)
-	move(crates, 1, 9, 1)
-	move(crates, 4, 9, 2)
-	move(crates, 15, 2, 7)
-	move(crates, 14, 1, 7)
-	move(crates, 5, 5, 1)
-	move(crates, 9, 7, 2)
-	move(crates, 1, 9, 6)
-	move(crates, 1, 7, 4)
-	move(crates, 1, 4, 6)
-	move(crates, 2, 6, 2)
-	move(crates, 9, 2, 5)
-	move(crates, 4, 2, 4)
-	move(crates, 4, 7, 5)
-	move(crates, 6, 5, 9)
-	move(crates, 7, 1, 8)
-	move(crates, 6, 2, 8)
-	move(crates, 1, 1, 2)
-	move(crates, 3, 9, 5)
-	move(crates, 18, 7, 8)
-	move(crates, 2, 4, 6)
-	move(crates, 2, 4, 6)
-	move(crates, 3, 7, 6)
-	move(crates, 3, 5, 3)
-	move(crates, 1, 2, 6)
-	move(crates, 5, 6, 8)
-	move(crates, 29, 8, 1)
-	move(crates, 2, 3, 5)
-	move(crates, 25, 1, 6)
-	move(crates, 2, 9, 5)
-	move(crates, 1, 7, 8)
-	move(crates, 6, 8, 2)
-	move(crates, 1, 9, 1)
-	move(crates, 15, 6, 8)
-	move(crates, 1, 3, 8)
-	move(crates, 14, 8, 7)
-	move(crates, 5, 1, 3)
-	move(crates, 1, 6, 2)
-	move(crates, 2, 5, 7)
-	move(crates, 10, 6, 2)
-	move(crates, 4, 5, 7)
-	move(crates, 6, 5, 1)
-	move(crates, 2, 1, 4)
-	move(crates, 19, 7, 9)
+	var steps []step
+	for s.Scan() {
+		if s.Text() == "" {
+			continue
+		}
+		var st step
+		if _, err := fmt.Sscanf(s.Text(), "move %d from %d to %d", &st.qty, &st.from, &st.to); err != nil {
+			return nil, nil, fmt.Errorf("invalid step %q: %w", s.Text(), err)
+		}
+		steps = append(steps, st)
+	}
 
-	output := make([]byte, len(crates)-1)
-	for i := 1; i < len(crates); i++ {
-		output[i-1] = crates[i][len(crates[i])-1]
+	if err := s.Err(); err != nil {
+		return nil, nil, err
 	}
-	return output
+	return stacks, steps, nil
 }
 
-func move(c []crate, qty, from, to int) {
-	split := len(c[from]) - qty
+// parseDrawing parses the drawing of the stacks of crates.  The last line
+// has the number of each stack, and each crate is drawn as [X] above the
+// number of its stack.
+func parseDrawing(lines []string) ([]stack, error) {
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("missing the drawing of the stacks")
+	}
 
-	top := c[from][split:]
-	c[from] = c[from][:split]
+	labels := lines[len(lines)-1]
+	n := len(strings.Fields(labels))
+	if n == 0 {
+		return nil, fmt.Errorf("missing the stack numbers: %q", labels)
+	}
 
-	c[to] = append(c[to], top...)
+	stacks := make([]stack, n+1)
+	for y := len(lines) - 2; y >= 0; y-- {
+		line := lines[y]
+		for i := 1; i <= n; i++ {
+			x := 4*i - 3
+			if x >= len(line) || line[x] == ' ' {
+				continue
+			}
+			if line[x-1] != '[' {
+				return nil, fmt.Errorf("line %d: invalid crate at column %d: %q", y+1, x+1, line)
+			}
+			stacks[i] = append(stacks[i], line[x])
+		}
+	}
 
-	// for i := len(top) - 1; i >= 0; i-- {
-	// 	c[to] = append(c[to], top[i])
-	// }
+	return stacks, nil
 }
@@ -0,0 +1,79 @@
+package day05
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	stacks, steps, err := read(strings.NewReader(_sample))
+	require.NoError(t, err)
+
+	a := assert.New(t)
+	a.Equal([]stack{nil, stack("ZN"), stack("MCD"), stack("P")}, stacks)
+	a.Equal([]step{
+		{qty: 1, from: 2, to: 1},
+		{qty: 3, from: 1, to: 3},
+		{qty: 2, from: 2, to: 1},
+		{qty: 1, from: 1, to: 2},
+	}, steps)
+}
+
+func TestSolve(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		in   string
+		m    Mover
+		want string
+		err  bool
+	}{
+		{name: "part 1", in: _sample, m: CrateMover9000, want: "CMZ"},
+		{name: "part 2", in: _sample, m: CrateMover9001, want: "MCD"},
+		{
+			name: "one crane moves crates onto the same stack",
+			in:   "[A]\n[B]\n[C]\n 1 \n\nmove 2 from 1 to 1\n",
+			m:    CrateMover9000,
+			want: "B",
+		},
+		{
+			name: "the other crane moves crates onto the same stack",
+			in:   "[A]\n[B]\n[C]\n 1 \n\nmove 2 from 1 to 1\n",
+			m:    CrateMover9001,
+			want: "A",
+		},
+		{
+			name: "too many crates",
+			in:   "[A]\n 1 \n\nmove 2 from 1 to 1\n",
+			m:    CrateMover9000,
+			err:  true,
+		},
+		{
+			name: "no such stack",
+			in:   "[A]\n 1 \n\nmove 1 from 1 to 2\n",
+			m:    CrateMover9001,
+			err:  true,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := solve(strings.NewReader(tc.in), tc.m)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -1,38 +1,35 @@
-package main
+package day06
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"io"
-	"log"
-	"os"
-	"time"
-)
-
-func main() {
-	data, err := os.ReadFile("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	start := time.Now()
-	p1, err := part1(data)
-	if err != nil {
-		log.Fatalf("part1: %d", err)
-	}
-
-	middle := time.Now()
 
-	p2, err := part2(data)
-	if err != nil {
-		log.Fatalf("part2: %s", err)
-	}
-	end := time.Now()
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+)
 
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+func init() {
+	puzzle.RegisterSample(6, _sample)
+	puzzle.Register(6,
+		func(_ context.Context, r io.Reader) (any, error) {
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(data)
+		},
+		func(_ context.Context, r io.Reader) (any, error) {
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(data)
+		})
 }
 
+// _sample is the first example input from the puzzle description.
+const _sample = `mjqjpqmgbljsphdztnvjfqwrcgsmlb`
+
 // part1 returns the index of the start-of-packet marker in the given data.
 // A start-of-packet marker occurs *after* a sequence of 4 unique bytes.
 // Returns io.EOF if the start-of-packet marker is not found.
@@ -1,37 +1,41 @@
-package main
+package day08
 
 import (
 	"bufio"
-	"fmt"
+	"context"
 	"io"
-	"log"
-	"os"
-	"time"
-)
-
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	start := time.Now()
-
-	forest, err := NewForest(file)
-	if err != nil {
-		log.Fatal(err)
-	}
 
-	p1 := part1(forest)
-	middle := time.Now()
-	p2 := part2(forest)
-	end := time.Now()
+	"github.com/nealmcc/aoc2022/pkg/collection"
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+)
 
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+func init() {
+	puzzle.RegisterSample(8, _sample)
+	puzzle.Register(8,
+		func(_ context.Context, r io.Reader) (any, error) {
+			forest, err := NewForest(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(forest), nil
+		},
+		func(_ context.Context, r io.Reader) (any, error) {
+			forest, err := NewForest(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(forest), nil
+		})
 }
 
+// _sample is the example input from the puzzle description.
+const _sample = `30373
+25512
+65332
+33549
+35390
+`
+
 // Visibility counts the number of trees in the forest that are visible from
 // outside the forest.  A tree is visible from a given direction if all of the
 // other trees between it and an edge of the grid are shorter than it.
@@ -73,7 +77,7 @@ func (f Forest) Visibility() Mask {
 	for r := 0; r < len(f); r++ {
 		for c := 0; c < len(f[r]); c++ {
 			pos := Pos{Row: r, Col: c}
-			if dirs := f.visibilityAt(pos); dirs > 0 {
+			if dirs := f.visibilityAt(pos); dirs.Len() > 0 {
 				visibleTrees[pos] = dirs
 			}
 		}
@@ -100,14 +104,14 @@ func (f Forest) SceneScore() (Pos, int) {
 	return best, max
 }
 
-func (f Forest) visibilityAt(pos Pos) Direction {
-	vis := top + bottom + right + left
+func (f Forest) visibilityAt(pos Pos) collection.BitSet64 {
+	vis := collection.NewBitSet64(top, right, bottom, left)
 	height := f[pos.Row][pos.Col]
 
 	// visibility from the top:
 	for r, c := pos.Row-1, pos.Col; r >= 0; r-- {
 		if f[r][c] >= height {
-			vis -= top
+			vis.Remove(top)
 			break
 		}
 	}
@@ -115,7 +119,7 @@ func (f Forest) visibilityAt(pos Pos) Direction {
 	// visibility from the right:
 	for r, c := pos.Row, pos.Col+1; c < len(f[r]); c++ {
 		if f[r][c] >= height {
-			vis -= right
+			vis.Remove(right)
 			break
 		}
 	}
@@ -123,7 +127,7 @@ func (f Forest) visibilityAt(pos Pos) Direction {
 	// visibility from the bottom:
 	for r, c := pos.Row+1, pos.Col; r < len(f); r++ {
 		if f[r][c] >= height {
-			vis -= bottom
+			vis.Remove(bottom)
 			break
 		}
 	}
@@ -131,7 +135,7 @@ func (f Forest) visibilityAt(pos Pos) Direction {
 	// visibility from the left:
 	for r, c := pos.Row, pos.Col-1; c >= 0; c-- {
 		if f[r][c] >= height {
-			vis -= left
+			vis.Remove(left)
 			break
 		}
 	}
@@ -1,17 +1,10 @@
-package main
+package day08
 
 import (
 	"strings"
 	"testing"
 )
 
-const _sample = `30373
-25512
-65332
-33549
-35390
-`
-
 func TestPart1(t *testing.T) {
 	t.Parallel()
 
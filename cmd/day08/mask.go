@@ -1,6 +1,10 @@
-package main
+package day08
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/nealmcc/aoc2022/pkg/collection"
+)
 
 // Pos is a position on a 2d grid
 type Pos struct {
@@ -8,24 +12,25 @@ type Pos struct {
 	Col int
 }
 
-// Direction is a bitmask for one or more of N, E, S, W
-type Direction byte
-
+// The directions that a tree can be seen from, as values in a BitSet64.
+// Printed in hex, each set is one digit: top is 1, right is 2, bottom is 4
+// and left is 8.
 const (
-	top    Direction = 1 << iota // 1
-	right                        // 2
-	bottom                       // 4
-	left                         // 8
+	top = iota
+	right
+	bottom
+	left
 )
 
-// Mask is a 2d grid of bitwise masks showing which directions each tree is visible from.
-type Mask map[Pos]Direction
+// Mask is a 2d grid of sets showing which directions each tree is visible from.
+type Mask map[Pos]collection.BitSet64
 
-func (m Mask) Filter(dir Direction) Mask {
+// Filter returns the trees that are visible from the given direction.
+func (m Mask) Filter(dir int) Mask {
 	out := make(Mask)
 	for coord, val := range m {
-		if val&dir > 0 {
-			out[coord] = dir
+		if val.Contains(dir) {
+			out[coord] = collection.NewBitSet64(dir)
 		}
 	}
 	return out
@@ -45,11 +50,11 @@ func (m Mask) size() (int, int) {
 }
 
 // ToSlice converts the sparsely populated bitmask to a fully populated matrix.
-func (m Mask) ToSlice() [][]Direction {
+func (m Mask) ToSlice() [][]collection.BitSet64 {
 	w, h := m.size()
-	data := make([][]Direction, h)
+	data := make([][]collection.BitSet64, h)
 	for row := 0; row < w; row++ {
-		data[row] = make([]Direction, w)
+		data[row] = make([]collection.BitSet64, w)
 	}
 
 	for pos, val := range m {
@@ -72,7 +77,7 @@ func (m Mask) Format(s fmt.State, verb rune) {
 	pad := (width - w) / 2
 	for row := 0; row <= height; row++ {
 		for col := -1 * pad; col <= width+pad; col++ {
-			buf[col+pad] = fmt.Sprintf("%x", m[Pos{row, col}])[0]
+			buf[col+pad] = fmt.Sprintf("%x", uint64(m[Pos{row, col}]))[0]
 		}
 		s.Write(append(buf, '\n'))
 	}
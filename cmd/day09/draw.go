@@ -1,4 +1,4 @@
-package main
+package day09
 
 import (
 	"fmt"
@@ -34,6 +34,7 @@ type tracer struct {
 	prefix     string
 	numSaved   int
 	count      int
+	err        error // the first error from saving the frames
 }
 
 func newTracer(prefix string) *tracer {
@@ -61,12 +62,16 @@ func (t *tracer) Log(knots []v.Point) {
 	}
 	t.frames = append(t.frames, m)
 	if len(t.frames) >= 24 {
-		t.Save()
+		t.err = t.Save()
 	}
 }
 
-// Save the tracer's current image to the given output.
+// Save the frames that the tracer has drawn since it last saved them, and
+// return the first error from saving any of its frames.
 func (t *tracer) Save() error {
+	if t.err != nil {
+		return t.err
+	}
 	for _, img := range t.frames {
 		if err := save(fmt.Sprintf("%s_%04d.png", t.prefix, t.numSaved), img); err != nil {
 			return err
@@ -1,52 +1,59 @@
-package main
+package day09
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"log"
-	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
+	"github.com/nealmcc/aoc2022/pkg/collection"
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 	"github.com/nealmcc/aoc2022/pkg/rope"
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 )
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	start := time.Now()
-
-	p1, err := solve(file, 2, "part1")
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	middle := time.Now()
-
-	file.Seek(0, io.SeekStart)
+func init() {
+	puzzle.RegisterSample(9, _sample)
+	puzzle.Register(9,
+		func(ctx context.Context, r io.Reader) (any, error) { return solve(r, 2, imagePrefix(ctx, "part1")) },
+		func(ctx context.Context, r io.Reader) (any, error) { return solve(r, 10, imagePrefix(ctx, "part2")) })
+}
 
-	p2, err := solve(file, 10, "part2")
-	if err != nil {
-		log.Fatal(err)
+// imagePrefix returns the path prefix for the images of the given part, or
+// an empty string if the runner has not asked for them.
+func imagePrefix(ctx context.Context, part string) string {
+	dir := puzzle.OutputDir(ctx)
+	if dir == "" {
+		return ""
 	}
-	end := time.Now()
-
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+	return filepath.Join(dir, "day09_"+part)
 }
 
-// solve solves both part 1 and part 2
+// _sample is the first example input from the puzzle description.
+const _sample = `R 4
+U 4
+L 3
+D 1
+R 4
+D 1
+L 5
+R 2
+`
+
+// solve solves both part 1 and part 2.  If imagePrefix is not empty, it
+// also draws the first moves of the rope, and saves them as images.
 func solve(r io.Reader, n int, imagePrefix string) (int, error) {
-	log := &logger{tailPositions: make(map[v.Point]struct{})}
-	trace := newTracer(imagePrefix)
-	rope := rope.New(n, tee(log, trace))
+	log := &logger{tailPositions: make(collection.Set[v.Point])}
+	var trace *tracer
+	var ropeLog rope.Logger = log
+	if imagePrefix != "" {
+		trace = newTracer(imagePrefix)
+		ropeLog = tee(log, trace)
+	}
+	rope := rope.New(n, ropeLog)
 
 	s := bufio.NewScanner(r)
 	line := 0
@@ -68,13 +75,17 @@ func solve(r io.Reader, n int, imagePrefix string) (int, error) {
 		return 0, err
 	}
 
-	trace.Save()
-	return len(log.tailPositions), nil
+	if trace != nil {
+		if err := trace.Save(); err != nil {
+			return 0, err
+		}
+	}
+	return log.tailPositions.Len(), nil
 }
 
 // logger is responsible for recording the movement of the rope.
 type logger struct {
-	tailPositions map[v.Point]struct{}
+	tailPositions collection.Set[v.Point]
 }
 
 // compile-time interface check:
@@ -83,7 +94,7 @@ var _ rope.Logger = new(logger)
 // Log implements rope.Logger
 func (l *logger) Log(knots []v.Point) {
 	tail := knots[len(knots)-1]
-	l.tailPositions[tail] = struct{}{}
+	l.tailPositions.Add(tail)
 }
 
 // parse the given input line into a direction and distance.
@@ -1,4 +1,4 @@
-package main
+package day09
 
 import (
 	"strings"
@@ -15,7 +15,7 @@ D 1
 L 5
 R 2`
 
-	got, err := solve(strings.NewReader(sample), 2, "sample1")
+	got, err := solve(strings.NewReader(sample), 2, "")
 	if err != nil {
 		t.Log(err)
 		t.Fail()
@@ -38,7 +38,7 @@ D 10
 L 25
 U 20`
 
-	got, err := solve(strings.NewReader(sample), 10, "sample2")
+	got, err := solve(strings.NewReader(sample), 10, "")
 	if err != nil {
 		t.Log(err)
 		t.Fail()
@@ -1,28 +1,22 @@
-package main
+package day11
 
 // Item represents an item that the monkeys are tossing around.
-// Each item's value is stored modulo 9699690 (2 * 3 * 5 * 7 * 11 * 13 * 17 * 19).
-// The items integer value can by calculated as 9699690 * div + mod
-type Item struct {
-	div int
-	mod int
-}
-
-const _lcm = 2 * 3 * 5 * 7 * 11 * 13 * 17 * 19 * 23
+// Its value is the worry level for that item.
+type Item int
 
-// NewItem converts an integer to an Item.
-func NewItem(n int) Item {
-	return Item{
-		div: n / _lcm,
-		mod: n % _lcm,
+// lcm returns the least common multiple of the given positive integers.
+func lcm(nums ...int) int {
+	m := 1
+	for _, n := range nums {
+		m = m / gcd(m, n) * n
 	}
+	return m
 }
 
-// reduce returns a copy of the given item with the same value, expressed
-// with the lowest positive modulo that it can.
-func reduce(n Item) Item {
-	return Item{
-		div: n.div + n.mod/_lcm,
-		mod: n.mod % _lcm,
+// gcd returns the greatest common divisor of a and b.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
 	}
+	return a
 }
@@ -1,104 +1,244 @@
-package main
+package day11
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"sort"
-	"time"
+	"strconv"
+	"strings"
 
 	"github.com/nealmcc/aoc2022/pkg/collection"
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 )
 
-func main() {
-	start := time.Now()
-	answer := solve(barrel())
-	end := time.Now()
-	fmt.Printf("solution: %d in %s\n", answer, end.Sub(start))
+func init() {
+	puzzle.RegisterSample(11, _sample)
+	puzzle.Register(11,
+		func(_ context.Context, r io.Reader) (any, error) {
+			t, err := read(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(t), nil
+		},
+		func(_ context.Context, r io.Reader) (any, error) {
+			t, err := read(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(t), nil
+		})
 }
 
-func solve(t Troop) int64 {
-	active := make([]int, len(t))
+// _sample is the example input from the puzzle description.
+const _sample = `Monkey 0:
+  Starting items: 79, 98
+  Operation: new = old * 19
+  Test: divisible by 23
+    If true: throw to monkey 2
+    If false: throw to monkey 3
+
+Monkey 1:
+  Starting items: 54, 65, 75, 74
+  Operation: new = old + 6
+  Test: divisible by 19
+    If true: throw to monkey 2
+    If false: throw to monkey 0
+
+Monkey 2:
+  Starting items: 79, 60, 97
+  Operation: new = old * old
+  Test: divisible by 13
+    If true: throw to monkey 1
+    If false: throw to monkey 3
+
+Monkey 3:
+  Starting items: 74
+  Operation: new = old + 3
+  Test: divisible by 17
+    If true: throw to monkey 0
+    If false: throw to monkey 1
+`
+
+// part1 is the level of monkey business after 20 rounds, when the worry
+// level of each item is divided by three after each inspection.
+func part1(t Troop) int {
+	return monkeyBusiness(t, 20, func(x Item) Item { return x / 3 })
+}
+
+// part2 is the level of monkey business after 10000 rounds, with no relief.
+// The worry levels are kept manageable by reducing them modulo the troop's
+// modulus, which does not change the outcome of any test.
+func part2(t Troop) int {
+	return monkeyBusiness(t, 10000, func(x Item) Item { return x % Item(t.modulus) })
+}
+
+// monkeyBusiness runs the given number of rounds, and then returns the
+// product of the number of inspections by the two most active monkeys.
+func monkeyBusiness(t Troop, rounds int, relief ReliefFunc) int {
+	active := make([]int, len(t.monkeys))
 	logFn := func(id int, _ Item) {
 		active[id]++
 	}
 
-	for i := 0; i < 10000; i++ {
-		t.Round(logFn)
+	for i := 0; i < rounds; i++ {
+		t.Round(relief, logFn)
 	}
 
 	sort.Slice(active, func(i, j int) bool {
 		return active[i] > active[j]
 	})
-	return int64(active[0] * active[1])
+	if len(active) < 2 {
+		return 0
+	}
+	return active[0] * active[1]
+}
+
+// read parses the notes about each monkey, and returns the troop.
+func read(r io.Reader) (Troop, error) {
+	var (
+		t       Troop
+		targets [][2]int // for each monkey, the ids of mTrue and mFalse
+		lines   []string
+	)
+
+	s := bufio.NewScanner(r)
+	for {
+		more := s.Scan()
+		if more && strings.TrimSpace(s.Text()) != "" {
+			lines = append(lines, s.Text())
+			continue
+		}
+
+		if len(lines) > 0 {
+			m, throws, err := parseMonkey(lines)
+			if err != nil {
+				return Troop{}, err
+			}
+			if m.id != len(t.monkeys) {
+				return Troop{}, fmt.Errorf("monkey %d: want monkey %d", m.id, len(t.monkeys))
+			}
+			t.monkeys = append(t.monkeys, m)
+			targets = append(targets, throws)
+			lines = lines[:0]
+		}
+
+		if !more {
+			break
+		}
+	}
+	if err := s.Err(); err != nil {
+		return Troop{}, err
+	}
+
+	divisors := make([]int, len(t.monkeys))
+	for i, throws := range targets {
+		for _, id := range throws {
+			if id < 0 || id >= len(t.monkeys) || id == i {
+				return Troop{}, fmt.Errorf("monkey %d: cannot throw to monkey %d", i, id)
+			}
+		}
+		t.monkeys[i].mTrue = &t.monkeys[throws[0]]
+		t.monkeys[i].mFalse = &t.monkeys[throws[1]]
+		divisors[i] = t.monkeys[i].divisor
+	}
+	t.modulus = lcm(divisors...)
+
+	return t, nil
+}
+
+// parseMonkey parses the notes about a single monkey.  It also returns the
+// ids of the monkeys that it throws to when the test is true and false.
+func parseMonkey(lines []string) (Monkey, [2]int, error) {
+	var (
+		m      Monkey
+		throws [2]int
+	)
+
+	if len(lines) != 6 {
+		return m, throws, fmt.Errorf("want 6 lines for each monkey; got %d: %q", len(lines), lines)
+	}
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+
+	if _, err := fmt.Sscanf(lines[0], "Monkey %d:", &m.id); err != nil {
+		return m, throws, fmt.Errorf("%q: %w", lines[0], err)
+	}
+
+	const itemsPrefix = "Starting items:"
+	if !strings.HasPrefix(lines[1], itemsPrefix) {
+		return m, throws, fmt.Errorf("monkey %d: want starting items; got %q", m.id, lines[1])
+	}
+	m.items = collection.NewQueue[Item]()
+	for _, field := range strings.Split(lines[1][len(itemsPrefix):], ",") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return m, throws, fmt.Errorf("monkey %d: invalid item: %w", m.id, err)
+		}
+		m.items.Push(Item(n))
+	}
+
+	op, err := parseOperation(lines[2])
+	if err != nil {
+		return m, throws, fmt.Errorf("monkey %d: %w", m.id, err)
+	}
+	m.inspect = op
+
+	if _, err := fmt.Sscanf(lines[3], "Test: divisible by %d", &m.divisor); err != nil {
+		return m, throws, fmt.Errorf("monkey %d: %q: %w", m.id, lines[3], err)
+	}
+	if m.divisor < 1 {
+		return m, throws, fmt.Errorf("monkey %d: invalid divisor %d", m.id, m.divisor)
+	}
+
+	if _, err := fmt.Sscanf(lines[4], "If true: throw to monkey %d", &throws[0]); err != nil {
+		return m, throws, fmt.Errorf("monkey %d: %q: %w", m.id, lines[4], err)
+	}
+	if _, err := fmt.Sscanf(lines[5], "If false: throw to monkey %d", &throws[1]); err != nil {
+		return m, throws, fmt.Errorf("monkey %d: %q: %w", m.id, lines[5], err)
+	}
+
+	return m, throws, nil
 }
 
-// barrel initializes the barrel of monkeys.
-// I've hard-coded this instead of parsing the input, since there are only 8 monkeys,
-// and my solution is based on the specific prime divisors that are in my input.
-//
-// Now that I know what part 2 is, I *could* write a parser to read the input,
-// and calculate a dynamic LCM for the items, but... I've already solved the
-// problem, and it doesn't feel worth it.
-func barrel() Troop {
-	x := make(Troop, 8)
-	for id := 0; id < 8; id++ {
-		x[id] = Monkey{id: id}
-	}
-
-	// Monkey 0:
-	x[0].items = collection.NewQueue(NewItem(72), NewItem(97))
-	x[0].inspect = times(13)
-	x[0].test = divisible(19)
-	x[0].mTrue = &x[5]
-	x[0].mFalse = &x[6]
-
-	// Monkey 1:
-	x[1].items = collection.NewQueue(NewItem(55), NewItem(70), NewItem(90), NewItem(74), NewItem(95))
-	x[1].inspect = square
-	x[1].test = divisible(7)
-	x[1].mTrue = &x[5]
-	x[1].mFalse = &x[0]
-
-	// Monkey 2:
-	x[2].items = collection.NewQueue(NewItem(74), NewItem(97), NewItem(66), NewItem(57))
-	x[2].inspect = plus(6)
-	x[2].test = divisible(17)
-	x[2].mTrue = &x[1]
-	x[2].mFalse = &x[0]
-
-	// Monkey 3:
-	x[3].items = collection.NewQueue(NewItem(86), NewItem(54), NewItem(53))
-	x[3].inspect = plus(2)
-	x[3].test = divisible(13)
-	x[3].mTrue = &x[1]
-	x[3].mFalse = &x[2]
-
-	// Monkey 4:
-	x[4].items = collection.NewQueue(NewItem(50), NewItem(65), NewItem(78), NewItem(50), NewItem(62), NewItem(99))
-	x[4].inspect = plus(3)
-	x[4].test = divisible(11)
-	x[4].mTrue = &x[3]
-	x[4].mFalse = &x[7]
-
-	// Monkey 5:
-	x[5].items = collection.NewQueue(NewItem(90))
-	x[5].inspect = plus(4)
-	x[5].test = divisible(2)
-	x[5].mTrue = &x[4]
-	x[5].mFalse = &x[6]
-
-	// Monkey 6:
-	x[6].items = collection.NewQueue(NewItem(88), NewItem(92), NewItem(63), NewItem(94), NewItem(96), NewItem(82), NewItem(53), NewItem(53))
-	x[6].inspect = plus(8)
-	x[6].test = divisible(5)
-	x[6].mTrue = &x[4]
-	x[6].mFalse = &x[7]
-
-	// Monkey 7:
-	x[7].items = collection.NewQueue(NewItem(70), NewItem(60), NewItem(71), NewItem(69), NewItem(77), NewItem(70), NewItem(98))
-	x[7].inspect = times(7)
-	x[7].test = divisible(3)
-	x[7].mTrue = &x[2]
-	x[7].mFalse = &x[3]
-
-	return x
+// parseOperation parses a line such as "Operation: new = old * 19".
+func parseOperation(line string) (inspectFunc, error) {
+	const prefix = "Operation: new ="
+	if !strings.HasPrefix(line, prefix) {
+		return nil, fmt.Errorf("want an operation; got %q", line)
+	}
+
+	fields := strings.Fields(line[len(prefix):])
+	if len(fields) != 3 || len(fields[1]) != 1 {
+		return nil, fmt.Errorf("invalid operation %q", line)
+	}
+
+	operand := func(s string) (*Item, error) {
+		if s == "old" {
+			return nil, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operand in %q: %w", line, err)
+		}
+		x := Item(n)
+		return &x, nil
+	}
+
+	lhs, err := operand(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	rhs, err := operand(fields[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return operation(lhs, fields[1][0], rhs)
 }
@@ -1,50 +1,89 @@
-package main
+package day11
 
 import (
+	"strings"
 	"testing"
 
-	"github.com/nealmcc/aoc2022/pkg/collection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestSolve(t *testing.T) {
-	got := solve(sample())
+func TestRead(t *testing.T) {
+	t.Parallel()
 
-	want := int64(2713310158)
+	troop, err := read(strings.NewReader(_sample))
+	require.NoError(t, err)
+
+	a := assert.New(t)
+	a.Len(troop.monkeys, 4)
+	a.Equal(23*19*13*17, troop.modulus)
+
+	m := troop.monkeys[2]
+	a.Equal(3, m.items.Len())
+	a.Equal(Item(81), m.inspect(9), "old * old")
+	a.Equal(13, m.divisor)
+	a.Same(&troop.monkeys[1], m.mTrue)
+	a.Same(&troop.monkeys[3], m.mFalse)
+}
+
+func TestParseOperation(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		in   string
+		want Item
+		err  bool
+	}{
+		{in: "Operation: new = old * 19", want: 190},
+		{in: "Operation: new = old + 6", want: 16},
+		{in: "Operation: new = old * old", want: 100},
+		{in: "Operation: new = old + old", want: 20},
+		{in: "Operation: new = 3 + old", want: 13},
+		{in: "Operation: new = old - 2", err: true},
+		{in: "Operation: new = old * x", err: true},
+		{in: "Test: divisible by 19", err: true},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+
+			op, err := parseOperation(tc.in)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, op(10))
+		})
+	}
+}
+
+func TestPart1(t *testing.T) {
+	troop, err := read(strings.NewReader(_sample))
+	require.NoError(t, err)
+
+	got, want := part1(troop), 10605
 	if got != want {
 		t.Logf("part1(sample) = %d ; want %d", got, want)
 		t.Fail()
 	}
 }
 
-func sample() Troop {
-	x := make(Troop, 4)
-	for id := 0; id < 4; id++ {
-		x[id] = Monkey{id: id}
+func TestPart2(t *testing.T) {
+	troop, err := read(strings.NewReader(_sample))
+	require.NoError(t, err)
+
+	got, want := part2(troop), 2713310158
+	if got != want {
+		t.Logf("part2(sample) = %d ; want %d", got, want)
+		t.Fail()
 	}
+}
 
-	x[0].items = collection.NewQueue(NewItem(79), NewItem(98))
-	x[0].inspect = times(19)
-	x[0].test = divisible(23)
-	x[0].mTrue = &x[2]
-	x[0].mFalse = &x[3]
-
-	x[1].items = collection.NewQueue(NewItem(54), NewItem(65), NewItem(75), NewItem(74))
-	x[1].inspect = plus(6)
-	x[1].test = divisible(19)
-	x[1].mTrue = &x[2]
-	x[1].mFalse = &x[0]
-
-	x[2].items = collection.NewQueue(NewItem(79), NewItem(60), NewItem(97))
-	x[2].inspect = square
-	x[2].test = divisible(13)
-	x[2].mTrue = &x[1]
-	x[2].mFalse = &x[3]
-
-	x[3].items = collection.NewQueue(NewItem(74))
-	x[3].inspect = plus(3)
-	x[3].test = divisible(17)
-	x[3].mTrue = &x[0]
-	x[3].mFalse = &x[1]
-
-	return x
+func TestLCM(t *testing.T) {
+	assert.Equal(t, 12, lcm(4, 6))
+	assert.Equal(t, 2*3*5*7*11*13*17*19, lcm(2, 3, 5, 7, 11, 13, 17, 19))
+	assert.Equal(t, 1, lcm())
 }
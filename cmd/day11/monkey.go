@@ -1,4 +1,4 @@
-package main
+package day11
 
 import (
 	"fmt"
@@ -10,28 +10,30 @@ import (
 type Monkey struct {
 	id            int
 	items         *collection.Queue[Item]
-	test          testFunc
 	inspect       inspectFunc
+	divisor       int // the monkey tests if each worry level is divisible by this
 	mTrue, mFalse *Monkey
 }
 
 // LogFunc is a function that records each time a monkey inspects an item.
 type LogFunc func(id int, item Item)
 
-// these functions allow us to customise the monkey behaviour.
-type (
-	inspectFunc func(Item) Item
-	testFunc    func(Item) bool
-)
+// ReliefFunc adjusts an item's worry level after a monkey inspects it,
+// but before the monkey tests it.
+type ReliefFunc func(Item) Item
+
+// inspectFunc is the operation that a monkey performs when it inspects an item.
+type inspectFunc func(Item) Item
 
-// Turn processes a single turn for this monkey, using the given logger to
-// record the monkey's inspections.
-func (m *Monkey) Turn(log LogFunc) {
+// Turn processes a single turn for this monkey, using the given relief
+// function after each inspection, and the given logger to record the
+// monkey's inspections.
+func (m *Monkey) Turn(relief ReliefFunc, log LogFunc) {
 	for m.items.Len() > 0 {
 		x, _ := m.items.Pop()
 		log(m.id, x)
-		x = m.inspect(x)
-		if m.test(x) {
+		x = relief(m.inspect(x))
+		if int(x)%m.divisor == 0 {
 			m.mTrue.items.Push(x)
 		} else {
 			m.mFalse.items.Push(x)
@@ -42,7 +44,7 @@ func (m *Monkey) Turn(log LogFunc) {
 // Format implements fmt.Formatter.
 func (m *Monkey) Format(s fmt.State, _ rune) {
 	fmt.Fprintf(s, "Monkey %d:\n", m.id)
-	fmt.Fprintf(s, "  items: %v\n", *m.items)
+	fmt.Fprintf(s, "  items: %v\n", m.items.Slice())
 }
 
 // String implements fmt.Stringer.
@@ -51,46 +53,41 @@ func (m *Monkey) String() string {
 }
 
 // Troop is a group of Monkeys.
-type Troop []Monkey
+type Troop struct {
+	monkeys []Monkey
 
-// Round processes one turn for each monkey in the Troop, using the given log
-// function to record each time a monkey inspects an item.
-func (t Troop) Round(log LogFunc) {
-	for _, m := range t {
-		m.Turn(log)
-	}
+	// modulus is the least common multiple of the monkeys' divisors.
+	// Reducing a worry level modulo this number does not change the
+	// outcome of any monkey's test.
+	modulus int
 }
 
-// times generates a monkey operation that multiples an item's worry level.
-func times(n int) inspectFunc {
-	return func(x Item) Item {
-		return reduce(Item{
-			div: n * x.div,
-			mod: n * x.mod,
-		})
+// Round processes one turn for each monkey in the Troop, using the given
+// relief function after each inspection, and the given log function to
+// record each time a monkey inspects an item.
+func (t Troop) Round(relief ReliefFunc, log LogFunc) {
+	for i := range t.monkeys {
+		t.monkeys[i].Turn(relief, log)
 	}
 }
 
-// plus generates a monkey operation that adds to an item's worry level.
-func plus(n int) inspectFunc {
-	return func(x Item) Item {
-		return reduce(Item{
-			div: x.div,
-			mod: x.mod + n,
-		})
+// operation generates an inspectFunc that combines the two operands with
+// the given operator, which must be + or *.  An operand of nil means the
+// old worry level.
+func operation(lhs *Item, op byte, rhs *Item) (inspectFunc, error) {
+	value := func(operand *Item, old Item) Item {
+		if operand == nil {
+			return old
+		}
+		return *operand
 	}
-}
-
-// square is an inspectFunc that multiples the value of the item by itself.
-func square(x Item) Item {
-	return reduce(Item{
-		div: x.div*x.div + 2*x.div*x.mod,
-		mod: x.mod * x.mod,
-	})
-}
 
-// divisible generates a monkey test function to see if the item's worry level
-// is evenly divisible by n.
-func divisible(n int) testFunc {
-	return func(x Item) bool { return x.mod%n == 0 }
+	switch op {
+	case '+':
+		return func(old Item) Item { return value(lhs, old) + value(rhs, old) }, nil
+	case '*':
+		return func(old Item) Item { return value(lhs, old) * value(rhs, old) }, nil
+	default:
+		return nil, fmt.Errorf("unsupported operator %q", op)
+	}
 }
@@ -1,42 +1,44 @@
-package main
+package day12
 
 import (
 	"bufio"
-	"container/heap"
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"log"
-	"os"
-	"time"
 
-	pq "github.com/nealmcc/aoc2022/pkg/collection/prioqueue"
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+	"github.com/nealmcc/aoc2022/pkg/search"
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 )
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	hill, err := read(file)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	start := time.Now()
-
-	p1 := part1(hill)
-	middle := time.Now()
-	p2 := part2(hill)
-	end := time.Now()
-
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+func init() {
+	puzzle.RegisterSample(12, _sample)
+	puzzle.Register(12,
+		func(ctx context.Context, r io.Reader) (any, error) {
+			hill, err := read(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(ctx, hill)
+		},
+		func(ctx context.Context, r io.Reader) (any, error) {
+			hill, err := read(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(ctx, hill)
+		})
 }
 
+// _sample is the example input from the puzzle description.
+const _sample = `Sabqponm
+abcryxxl
+accszExk
+acctuvwj
+abdefghi
+`
+
 // read the terrain from the given input.
 func read(r io.Reader) (grid, error) {
 	s := bufio.NewScanner(r)
@@ -112,112 +114,49 @@ func (g *grid) Set(p v.Point, b byte) error {
 
 // part1 computes the shortest distance from the grid's start point to end point,
 // assuming you an only climb a maximum of 1 height per step.
-// Uses Dijkstra's Algorithm.
-func part1(g grid) int {
-	dist := make(map[v.Point]int, len(g.terrain))
-	dist[g.start] = 0
-
-	// push each node on the graph into the queue, with an initial distance
-	q := new(pq.Queue[v.Point])
-	// save a pointer to each node, so we can update it in the queue
-	pointers := make([]*pq.Node[v.Point], g.size*g.size)
-	const infinity = 1<<63 - 1
-	for pos := range g.terrain {
-		if pos != g.start {
-			dist[pos] = infinity
-		}
-		node := &pq.Node[v.Point]{Value: pos, Priority: -1 * dist[pos]}
-		pointers[pos.X*g.size+pos.Y] = node
-		heap.Push(q, node)
-	}
-
-	// the first node that we definitely know the distance to is the start.
-	// It has the highest priority (0 - all others are negative) so we pop it
-	// off the queue, and find the distance to all adjacent nodes.
-	// We keep working out from the next nearest node until all nodes have a defined distance.
-	for q.Len() > 0 {
-		n := heap.Pop(q).(*pq.Node[v.Point])
-		currPos, currTotal := n.Value, n.Priority*-1
-
-		for _, next := range g.neighbours(currPos) {
-			currHeight := g.terrain[currPos]
-			nextHeight := g.terrain[next]
-
-			if nextHeight > currHeight+1 {
-				// too steep - try another way.
-				continue
-			}
-
-			// the distance from start to next, if we arrive via curr:
-			alt := currTotal + 1
-			if alt < dist[next] {
-				dist[next] = alt
-				p := pointers[next.X*g.size+next.Y]
-				q.Update(p, p.Value, -1*alt)
-			}
-		}
+// Every step costs the same, so a breadth-first search is enough.
+func part1(ctx context.Context, g grid) (int, error) {
+	res, err := search.BFS[v.Point](ctx, g, g.start)
+	if err != nil {
+		return 0, err
 	}
-
-	return dist[g.end]
+	return res.Cost, nil
 }
 
 // part2 computes the shortest distance from any point with elevation 'a',
 // to the grid's end point, assuming you an only climb 1 height per step.
-// Uses Dijkstra's Algorithm.
-func part2(g grid) int {
-	dist := make(map[v.Point]int, len(g.terrain))
-	dist[g.start] = 0
-
-	// push each node on the graph into the queue, with an initial distance
-	q := new(pq.Queue[v.Point])
-	// save a pointer to each node, so we can update it in the queue
-	pointers := make([]*pq.Node[v.Point], g.size*g.size)
-	const infinity = 1<<63 - 1
+// The search starts from every such point at once.
+func part2(ctx context.Context, g grid) (int, error) {
+	starts := make([]v.Point, 0, len(g.terrain))
 	for pos, height := range g.terrain {
-		if height != 'a' {
-			dist[pos] = infinity
+		if height == 'a' {
+			starts = append(starts, pos)
 		}
-		node := &pq.Node[v.Point]{Value: pos, Priority: -1 * dist[pos]}
-		pointers[pos.X*g.size+pos.Y] = node
-		heap.Push(q, node)
 	}
 
-	// Working out from the next nearest node until all nodes have a defined cost.
-	for q.Len() > 0 {
-		n := heap.Pop(q).(*pq.Node[v.Point])
-		currPos, currTotal := n.Value, n.Priority*-1
-
-		for _, next := range g.neighbours(currPos) {
-			currHeight := g.terrain[currPos]
-			nextHeight := g.terrain[next]
-
-			if nextHeight > currHeight+1 {
-				// too steep - try another way.
-				continue
-			}
-
-			// the distance from start to next, if we arrive via curr:
-			alt := currTotal + 1
-			if alt < dist[next] {
-				dist[next] = alt
-				p := pointers[next.X*g.size+next.Y]
-				q.Update(p, p.Value, -1*alt)
-			}
-		}
+	res, err := search.BFS[v.Point](ctx, g, starts...)
+	if err != nil {
+		return 0, err
 	}
-
-	return dist[g.end]
+	return res.Cost, nil
 }
 
-// neighbours returns a slice of all positions within the grid that
-// are adjacent to the given position.
-// Squares are only adjacent vertically and horizontally - not diagonally.
-func (g grid) neighbours(pos v.Point) []v.Point {
-	points := make([]v.Point, 0, 4)
+// Neighbours implements search.Graph.  It returns a step to each position
+// within the grid that is adjacent to the given position, and not too steep
+// to climb.  Squares are only adjacent vertically and horizontally - not
+// diagonally.
+func (g grid) Neighbours(pos v.Point) []search.Edge[v.Point] {
+	edges := make([]search.Edge[v.Point], 0, 4)
 	for _, p := range pos.Neighbours4() {
-		if _, ok := g.terrain[p]; ok {
-			points = append(points, p)
+		height, ok := g.terrain[p]
+		if ok && height <= g.terrain[pos]+1 {
+			edges = append(edges, search.Edge[v.Point]{To: p, Cost: 1})
 		}
 	}
-	return points
+	return edges
+}
+
+// IsGoal implements search.Graph.
+func (g grid) IsGoal(pos v.Point) bool {
+	return pos == g.end
 }
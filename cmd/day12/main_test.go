@@ -1,6 +1,7 @@
-package main
+package day12
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
@@ -9,13 +10,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-const _sample = `Sabqponm
-abcryxxl
-accszExk
-acctuvwj
-abdefghi
-`
-
 func TestRead(t *testing.T) {
 	t.Parallel()
 
@@ -40,7 +34,13 @@ func TestPart1(t *testing.T) {
 		t.FailNow()
 	}
 
-	got, want := part1(hill), 31
+	got, err := part1(context.Background(), hill)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	want := 31
 
 	if got != want {
 		t.Logf("part1() =  %d; want %d", got, want)
@@ -57,7 +57,13 @@ func TestPart2(t *testing.T) {
 		t.FailNow()
 	}
 
-	got, want := part2(hill), 29
+	got, err := part2(context.Background(), hill)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	want := 29
 
 	if got != want {
 		t.Logf("part2() =  %d; want %d", got, want)
@@ -79,7 +85,7 @@ func BenchmarkPart2(b *testing.B) {
 
 	var result int
 	for n := 0; n < b.N; n++ {
-		result = part2(hill)
+		result, _ = part2(context.Background(), hill)
 	}
 	_result = result
 }
@@ -1,45 +1,61 @@
-package main
+package day13
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
-	"os"
 	"sort"
-	"time"
+
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 )
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
+func init() {
+	puzzle.RegisterSample(13, _sample)
+	puzzle.Register(13,
+		func(_ context.Context, r io.Reader) (any, error) {
+			lines, err := read(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(lines), nil
+		},
+		func(_ context.Context, r io.Reader) (any, error) {
+			lines, err := read(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(lines)
+		})
+}
 
-	start := time.Now()
+// _sample is the example input from the puzzle description.
+const _sample = `[1,1,3,1,1]
+[1,1,5,1,1]
 
-	lines, err := read(file)
-	if err != nil {
-		log.Fatal(err)
-	}
+[[1],[2,3,4]]
+[[1],4]
 
-	p1 := part1(lines)
+[9]
+[[8,7,6]]
 
-	middle := time.Now()
+[[4,4],4,4]
+[[4,4],4,4,4]
 
-	file.Seek(0, io.SeekStart)
-	p2, err := part2(lines)
-	if err != nil {
-		log.Fatal(err)
-	}
+[7,7,7,7]
+[7,7,7]
 
-	end := time.Now()
+[]
+[3]
 
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
-}
+[[[]]]
+[[]]
+
+[1,[2,[3,[4,[5,6,7]]]],8,9]
+[1,[2,[3,[4,[5,6,0]]]],8,9]
+`
 
 // data is either a float64 or a slice of float64.
 type data []any
@@ -1,4 +1,4 @@
-package main
+package day13
 
 import (
 	"strings"
@@ -7,31 +7,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-const _sample = `[1,1,3,1,1]
-[1,1,5,1,1]
-
-[[1],[2,3,4]]
-[[1],4]
-
-[9]
-[[8,7,6]]
-
-[[4,4],4,4]
-[[4,4],4,4,4]
-
-[7,7,7,7]
-[7,7,7]
-
-[]
-[3]
-
-[[[]]]
-[[]]
-
-[1,[2,[3,[4,[5,6,7]]]],8,9]
-[1,[2,[3,[4,[5,6,0]]]],8,9]
-`
-
 func TestRead(t *testing.T) {
 	t.Parallel()
 
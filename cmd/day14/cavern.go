@@ -1,4 +1,4 @@
-package main
+package day14
 
 import (
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
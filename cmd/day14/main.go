@@ -1,55 +1,52 @@
-package main
+package day14
 
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"log"
-	"os"
-	"time"
+	"path/filepath"
 
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 )
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	start := time.Now()
-
-	cave, err := read(file)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	animate := os.Getenv("ANIMATE")
-	var r RenderFunc
-	if animate != "" {
-		min := v.Point{X: 332, Y: -1}
-		max := v.Point{X: 669, Y: 168}
-		renderer := NewRenderer(cave, "part1", min, max, 10)
-		r = renderer.SaveNext
-	}
-
-	p1, err := part1(cave, r)
-	if err != nil {
-		log.Fatal(err)
-	}
-	middle := time.Now()
+func init() {
+	puzzle.RegisterSample(14, _sample)
+	puzzle.Register(14,
+		func(ctx context.Context, r io.Reader) (any, error) {
+			cave, err := read(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(cave, renderer(ctx, cave, "part1"))
+		},
+		func(ctx context.Context, r io.Reader) (any, error) {
+			cave, err := read(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(cave, renderer(ctx, cave, "part2"))
+		})
+}
 
-	p2, err := part2(cave, r)
-	if err != nil {
-		log.Fatal(err)
+// _sample is the example input from the puzzle description.
+const _sample = `498,4 -> 498,6 -> 496,6
+503,4 -> 502,4 -> 502,9 -> 494,9
+`
+
+// renderer returns a function to save each frame of the animation in the
+// runner's output directory, or nil if the runner has not asked for them.
+func renderer(ctx context.Context, cave *Cavern, part string) RenderFunc {
+	dir := puzzle.OutputDir(ctx)
+	if dir == "" {
+		return nil
 	}
-	end := time.Now()
-
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+	min := v.Point{X: 332, Y: -1}
+	max := v.Point{X: 669, Y: 168}
+	return NewRenderer(cave, filepath.Join(dir, "day14_"+part), min, max, 10).SaveNext
 }
 
 // read the lines from the given input.
@@ -1,16 +1,13 @@
-package main
+package day14
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 )
 
-const _sample = `498,4 -> 498,6 -> 496,6
-503,4 -> 502,4 -> 502,9 -> 494,9
-`
-
 func TestRead(t *testing.T) {
 	t.Parallel()
 
@@ -110,7 +107,8 @@ func TestRender(t *testing.T) {
 
 	min := v.Point{X: 488, Y: -1}
 	max := v.Point{X: 513, Y: 12}
-	r := NewRenderer(cave, "sample1", min, max, 10)
+	dir := t.TempDir()
+	r := NewRenderer(cave, filepath.Join(dir, "sample1"), min, max, 10)
 
 	_, err = part1(cave, r.SaveNext)
 	if err != nil {
@@ -118,7 +116,7 @@ func TestRender(t *testing.T) {
 		t.FailNow()
 	}
 
-	r.prefix = "sample2"
+	r.prefix = filepath.Join(dir, "sample2")
 	r.frame = 0
 
 	_, err = part2(cave, r.SaveNext)
@@ -1,4 +1,4 @@
-package main
+package day14
 
 import (
 	"bytes"
@@ -40,12 +40,15 @@ func (r *Renderer) SaveNext(points ...v.Point) error {
 
 	file, err := os.Create(r.prefix + fmt.Sprintf("_%05d", r.frame) + ".png")
 	if err != nil {
-		return nil
+		return err
 	}
-	defer file.Close()
 
 	img := r.cave.Render(r.min, r.max, r.scale, points...)
-	return png.Encode(file, img)
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
 }
 
 // Render renders the portion of this cavern as an image.
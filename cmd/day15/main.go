@@ -1,42 +1,55 @@
-package main
+package day15
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"regexp"
 	"strconv"
-	"time"
 
+	"github.com/nealmcc/aoc2022/pkg/bound"
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 )
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-	defer file.Close()
-
-	start := time.Now()
-
-	sensors, err := read(file)
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-
-	p1 := part1(sensors, 2000000)
-	middle := time.Now()
-
-	p2 := part2(sensors, 4000000)
-	end := time.Now()
-
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+func init() {
+	puzzle.RegisterSample(15, _sample)
+	puzzle.RegisterSampleParams(15, puzzle.Params{"row": 10, "limit": 20})
+	puzzle.Register(15,
+		func(ctx context.Context, r io.Reader) (any, error) {
+			sensors, err := read(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(sensors, puzzle.Param(ctx, "row", 2000000)), nil
+		},
+		func(ctx context.Context, r io.Reader) (any, error) {
+			sensors, err := read(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(sensors, puzzle.Param(ctx, "limit", 4000000)), nil
+		})
 }
 
+// _sample is the example input from the puzzle description.
+const _sample = `Sensor at x=2, y=18: closest beacon is at x=-2, y=15
+Sensor at x=9, y=16: closest beacon is at x=10, y=16
+Sensor at x=13, y=2: closest beacon is at x=15, y=3
+Sensor at x=12, y=14: closest beacon is at x=10, y=16
+Sensor at x=10, y=20: closest beacon is at x=10, y=16
+Sensor at x=14, y=17: closest beacon is at x=10, y=16
+Sensor at x=8, y=7: closest beacon is at x=2, y=10
+Sensor at x=2, y=0: closest beacon is at x=2, y=10
+Sensor at x=0, y=11: closest beacon is at x=2, y=10
+Sensor at x=20, y=14: closest beacon is at x=25, y=17
+Sensor at x=17, y=20: closest beacon is at x=21, y=22
+Sensor at x=16, y=7: closest beacon is at x=15, y=3
+Sensor at x=14, y=3: closest beacon is at x=15, y=3
+Sensor at x=20, y=1: closest beacon is at x=15, y=3
+`
+
 // read the lines from the given input.
 func read(r io.Reader) ([]Sensor, error) {
 	s := bufio.NewScanner(r)
@@ -96,66 +109,43 @@ func parseRow(b []byte) (Sensor, error) {
 
 // part1 solves part 1 of the puzzle:
 func part1(sensors []Sensor, y int) int {
-	segments := segmentsAt(sensors, y)
+	covered := new(bound.IntervalSet)
+	coverageAt(covered, sensors, y)
 
-	beacons := make(map[int]struct{}, 4)
+	// a position with a beacon is not one where the beacon cannot be:
 	for _, s := range sensors {
 		if s.Beacon.Y == y {
-			beacons[s.Beacon.X] = struct{}{}
+			covered.Remove(bound.Linear{Min: s.Beacon.X, Max: s.Beacon.X})
 		}
 	}
 
-	sum := 0
-	for _, seg := range segments {
-		sum += seg.Length()
-		for x := range beacons {
-			if x >= seg.From && x <= seg.To {
-				sum--
-			}
-		}
-	}
-
-	return sum
+	return covered.Len()
 }
 
 func part2(sensors []Sensor, limit int) int {
-	beacons := make(map[v.Point]struct{}, 27)
-	for _, s := range sensors {
-		beacons[s.Beacon] = struct{}{}
-	}
+	within := bound.Linear{Min: 0, Max: limit}
 
-	var x, y int
+	var (
+		x, y    int
+		covered = new(bound.IntervalSet)
+	)
 	for y = limit; y >= 0; y-- {
-		segments := Constrain(0, limit, segmentsAt(sensors, y))
-		min, max := limit+1, -1
-		for _, seg := range segments {
-			if seg.From < min {
-				min = seg.From
-			}
-			if seg.To > max {
-				max = seg.To
-			}
-		}
-		if min != 0 {
-			x = 0
-			break
-		} else if max != limit {
-			x = limit
-			break
-		} else if len(segments) == 2 {
-			x = segments[0].To + 1
+		coverageAt(covered, sensors, y)
+		if gaps := covered.Complement(within).Spans(); len(gaps) > 0 {
+			x = gaps[0].Min
 			break
 		}
 	}
 	return y + x*4000000
 }
 
-func segmentsAt(sensors []Sensor, y int) []Segment {
-	segments := make([]Segment, 0, len(sensors))
+// coverageAt fills covered with the positions on the line y that are within
+// range of at least one of the sensors, replacing anything it held before.
+func coverageAt(covered *bound.IntervalSet, sensors []Sensor, y int) {
+	covered.Clear()
 	for _, s := range sensors {
 		if seg, ok := s.SegmentAt(y); ok {
-			segments = append(segments, seg)
+			covered.Add(seg)
 		}
 	}
-	return JoinSegments(segments...)
 }
@@ -1,6 +1,7 @@
-package main
+package day15
 
 import (
+	"github.com/nealmcc/aoc2022/pkg/bound"
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 )
 
@@ -16,9 +17,9 @@ func (s Sensor) Radius() int {
 	return v.ManhattanLength(s.Beacon.Sub(s.Center))
 }
 
-// SegmentAt returns the line segment of points where this circle
+// SegmentAt returns the interval of points where this circle
 // intersects with the line y = n.
-func (s Sensor) SegmentAt(y int) (Segment, bool) {
+func (s Sensor) SegmentAt(y int) (bound.Linear, bool) {
 	rad := s.Radius()
 
 	dy := y - s.Center.Y
@@ -27,13 +28,13 @@ func (s Sensor) SegmentAt(y int) (Segment, bool) {
 	}
 
 	if dy > rad {
-		return Segment{}, false
+		return bound.Linear{}, false
 	}
 
 	dx := rad - dy
-	seg := Segment{
-		From: s.Center.X - dx,
-		To:   s.Center.X + dx,
+	seg := bound.Linear{
+		Min: s.Center.X - dx,
+		Max: s.Center.X + dx,
 	}
 
 	return seg, true
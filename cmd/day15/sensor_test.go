@@ -1,8 +1,9 @@
-package main
+package day15
 
 import (
 	"testing"
 
+	"github.com/nealmcc/aoc2022/pkg/bound"
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 )
 
@@ -72,13 +73,13 @@ func TestSensor_SegmentAt(t *testing.T) {
 		name   string
 		circle Sensor
 		y      int
-		want   Segment
+		want   bound.Linear
 	}{
 		{
 			name:   "radius 1 sensor, sliced through the middle => length of 3",
 			circle: Sensor{Beacon: v.Point{Y: 1}},
 			y:      0,
-			want:   Segment{From: -1, To: 1},
+			want:   bound.Linear{Min: -1, Max: 1},
 		},
 	}
 
@@ -1,46 +1,60 @@
-package main
+package day16
 
 import (
-	"container/heap"
-	"fmt"
-	"log"
-	"os"
-	"time"
+	"context"
+	"io"
 
+	"github.com/nealmcc/aoc2022/pkg/collection"
 	pq "github.com/nealmcc/aoc2022/pkg/collection/prioqueue"
+	"github.com/nealmcc/aoc2022/pkg/progress"
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 )
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-	defer file.Close()
-
-	graph, err := ReadValves(file)
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-	start := time.Now()
-
-	p1 := part1(graph)
-	middle := time.Now()
-
-	p2 := part2(graph)
-	end := time.Now()
-
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+func init() {
+	puzzle.RegisterSample(16, _sample)
+	puzzle.Register(16,
+		func(ctx context.Context, r io.Reader) (any, error) {
+			graph, err := ReadValves(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(ctx, graph, puzzle.Param(ctx, "beam", _beamWidth))
+		},
+		func(ctx context.Context, r io.Reader) (any, error) {
+			graph, err := ReadValves(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(ctx, graph, puzzle.Param(ctx, "beam", _beamWidth))
+		})
 }
 
+// _sample is the example input from the puzzle description.
+const _sample = `Valve AA has flow rate=0; tunnels lead to valves DD, II, BB
+Valve BB has flow rate=13; tunnels lead to valves CC, AA
+Valve CC has flow rate=2; tunnels lead to valves DD, BB
+Valve DD has flow rate=20; tunnels lead to valves CC, AA, EE
+Valve EE has flow rate=3; tunnels lead to valves FF, DD
+Valve FF has flow rate=0; tunnels lead to valves EE, GG
+Valve GG has flow rate=0; tunnels lead to valves FF, HH
+Valve HH has flow rate=22; tunnel leads to valve GG
+Valve II has flow rate=0; tunnels lead to valves AA, JJ
+Valve JJ has flow rate=21; tunnel leads to valve II
+`
+
 // state is a comparable struct that allows us to reduce the search space.
 // See https://go.dev/ref/spec for more about 'comparable' in Go.
 type state struct {
-	pos1       int    // current position
-	pos2       int    // current position of elephant (part 2)
-	openValves uint64 // which valves are currently open, expressed as a bitmask
+	pos1       int                 // current position
+	pos2       int                 // current position of elephant (part 2)
+	openValves collection.BitSet64 // which valves are currently open
 }
 
+// _beamWidth is the default number of states that are kept for each minute
+// of the search.  The runner can change it with the "beam" param; zero keeps
+// them all, which is how TestBeamWidth checks the default on the example.
+const _beamWidth = 1 << 16
+
 // part1 solves part 1 of the puzzle
 //
 // We simulate a turn-by-turn breadth-first traversal of the graph, and keep
@@ -54,10 +68,13 @@ type state struct {
 //
 // Due to the above requirement, we can't use a map or a slice to store the set
 // of currently open valves. Thankfully, we have less than 64 valves, so we can
-// use a uint64 bitmask, where each bit corresponds to a different valve, based
-// on its index within a slice. That's why we've had to add the 'ix' property to
+// use a collection.BitSet64, where each bit corresponds to a different valve,
+// based on its index within a slice. That's why we've had to add the 'ix' property to
 // the Valve, and store the Key in a slice, accessed by its index.
-func part1(input map[ValveID]*Valve) int {
+//
+// To keep the memory use predictable, only the best width states are kept
+// for the next minute (or all of them, if width is zero).
+func part1(ctx context.Context, input map[ValveID]*Valve, width int) (int, error) {
 	const limit = 30
 	_, valves := index(input)
 
@@ -65,57 +82,59 @@ func part1(input map[ValveID]*Valve) int {
 		return input[k].ix
 	}
 
-	// each round of evaluation uses a new queue.  This is the first.
-	qFirst := new(pq.Queue[state])
-	q := &qFirst
-	start := &pq.Node[state]{
-		Value:    state{pos1: getIndex(K("AA"))},
-		Priority: 0,
-	}
+	// each minute is a layer of the search, with the best states first.
+	layer := []*pq.Node[state, int]{{Value: state{pos1: getIndex(K("AA"))}}}
 
-	best := make(map[state]int)
-	heap.Push(*q, start)
+	meter, top := progress.NewMeter(ctx, ""), 0
 	for t := 1; t < limit; t++ {
-		qNext := new(pq.Queue[state])
-		pNext := make(map[state]*pq.Node[state])
-
-		for (*q).Len() > 0 {
-			curr := heap.Pop(*q).(*pq.Node[state])
+		qNext := pq.NewBeam[state](width, pq.MaxFirst[int])
+		best := make(map[state]int) // the best score of each state in this minute
+		pNext := make(map[state]*pq.Node[state, int])
+
+		for i, n := range layer {
+			curr, prio := n.Value, n.Priority
+			if prio > top {
+				top = prio
+			}
+			if err := meter.Expand(len(layer)-i-1+qNext.Len(), top); err != nil {
+				return 0, err
+			}
 
-			flow := valves[curr.Value.pos1].Flow
-			mask := uint64(1) << curr.Value.pos1
-			canOpen := flow > 0 && curr.Value.openValves&mask == 0
-			neighbours := valves[curr.Value.pos1].Neighbours
+			flow := valves[curr.pos1].Flow
+			canOpen := flow > 0 && !curr.openValves.Contains(curr.pos1)
+			neighbours := valves[curr.pos1].Neighbours
 
 			for _, n := range neighbours {
 				ix := getIndex(n)
-				k := state{pos1: ix, openValves: curr.Value.openValves}
-				upsert(qNext, &best, &pNext, k, curr.Priority)
+				k := state{pos1: ix, openValves: curr.openValves}
+				upsert(qNext, &best, &pNext, k, prio)
 			}
 
 			if canOpen {
 				// add a state where we open this valve
-				k := state{pos1: curr.Value.pos1, openValves: curr.Value.openValves | mask}
-				score := curr.Priority + (limit-t)*flow
+				k := state{pos1: curr.pos1, openValves: curr.openValves.With(curr.pos1)}
+				score := prio + (limit-t)*flow
 				upsert(qNext, &best, &pNext, k, score)
 			}
 
 		}
-		*q = qNext
+		layer = qNext.Drain()
 	}
 
-	max := 0
-	for _, v := range best {
-		if v > max {
-			max = v
+	// every state keeps its score in the next minute, so the best score so
+	// far is the answer once the final minute is included:
+	for _, n := range layer {
+		if n.Priority > top {
+			top = n.Priority
 		}
 	}
-	return max
+	meter.Done(0, top)
+	return top, nil
 }
 
 // part 2 works similarly to part 1, except that we track the elephant's
 // position as well as our own.
-func part2(input map[ValveID]*Valve) int {
+func part2(ctx context.Context, input map[ValveID]*Valve, width int) (int, error) {
 	const limit = 26
 	_, valves := index(input)
 
@@ -123,40 +142,39 @@ func part2(input map[ValveID]*Valve) int {
 		return input[k].ix
 	}
 
-	// each round of evaluation uses a new queue.  This is the first.
-	qFirst := new(pq.Queue[state])
-	q := &qFirst
-	start := &pq.Node[state]{
-		Value: state{
-			pos1: getIndex(K("AA")),
-			pos2: getIndex(K("AA")),
-		},
-		Priority: 0,
-	}
+	// each minute is a layer of the search, with the best states first.
+	layer := []*pq.Node[state, int]{{Value: state{
+		pos1: getIndex(K("AA")),
+		pos2: getIndex(K("AA")),
+	}}}
 
-	best := make(map[state]int, 13000000)
-	heap.Push(*q, start)
+	meter, top := progress.NewMeter(ctx, ""), 0
 	for t := 1; t < limit; t++ {
-		qNext := new(pq.Queue[state])
-		pNext := make(map[state]*pq.Node[state])
-
-		for (*q).Len() > 0 {
-			curr := heap.Pop(*q).(*pq.Node[state])
+		qNext := pq.NewBeam[state](width, pq.MaxFirst[int])
+		best := make(map[state]int) // the best score of each state in this minute
+		pNext := make(map[state]*pq.Node[state, int])
+
+		for i, n := range layer {
+			curr, prio := n.Value, n.Priority
+			if prio > top {
+				top = prio
+			}
+			if err := meter.Expand(len(layer)-i-1+qNext.Len(), top); err != nil {
+				return 0, err
+			}
 
-			flow1 := valves[curr.Value.pos1].Flow
-			mask1 := uint64(1) << curr.Value.pos1
-			canOpen1 := flow1 > 0 && curr.Value.openValves&mask1 == 0
-			neighbours1 := valves[curr.Value.pos1].Neighbours
+			flow1 := valves[curr.pos1].Flow
+			canOpen1 := flow1 > 0 && !curr.openValves.Contains(curr.pos1)
+			neighbours1 := valves[curr.pos1].Neighbours
 
-			flow2 := valves[curr.Value.pos2].Flow
-			mask2 := uint64(1) << curr.Value.pos2
-			canOpen2 := flow2 > 0 && curr.Value.openValves&mask2 == 0
-			neighbours2 := valves[curr.Value.pos2].Neighbours
+			flow2 := valves[curr.pos2].Flow
+			canOpen2 := flow2 > 0 && !curr.openValves.Contains(curr.pos2)
+			neighbours2 := valves[curr.pos2].Neighbours
 
 			if canOpen1 && canOpen2 {
 				// add a state where we both open the valve in our current room
-				k := state{curr.Value.pos1, curr.Value.pos2, curr.Value.openValves | mask1 | mask2}
-				score := curr.Priority + (limit-t)*(flow1+flow2)
+				k := state{curr.pos1, curr.pos2, curr.openValves.With(curr.pos1, curr.pos2)}
+				score := prio + (limit-t)*(flow1+flow2)
 				upsert(qNext, &best, &pNext, k, score)
 			}
 
@@ -164,9 +182,9 @@ func part2(input map[ValveID]*Valve) int {
 				// add states where I open the valve, but the elephant moves
 				for _, n2 := range neighbours2 {
 					ix := getIndex(n2)
-					if ix != curr.Value.pos1 {
-						k := state{curr.Value.pos1, ix, curr.Value.openValves | mask1}
-						score := curr.Priority + (limit-t)*flow1
+					if ix != curr.pos1 {
+						k := state{curr.pos1, ix, curr.openValves.With(curr.pos1)}
+						score := prio + (limit-t)*flow1
 						upsert(qNext, &best, &pNext, k, score)
 					}
 				}
@@ -176,9 +194,9 @@ func part2(input map[ValveID]*Valve) int {
 				// add states where the elephant opens the valve, but I move
 				for _, n1 := range neighbours1 {
 					ix := getIndex(n1)
-					if ix != curr.Value.pos2 {
-						k := state{ix, curr.Value.pos2, curr.Value.openValves | mask2}
-						score := curr.Priority + (limit-t)*flow2
+					if ix != curr.pos2 {
+						k := state{ix, curr.pos2, curr.openValves.With(curr.pos2)}
+						score := prio + (limit-t)*flow2
 						upsert(qNext, &best, &pNext, k, score)
 					}
 				}
@@ -192,28 +210,30 @@ func part2(input map[ValveID]*Valve) int {
 					if ix1 == ix2 {
 						continue
 					}
-					k := state{ix1, ix2, curr.Value.openValves}
-					upsert(qNext, &best, &pNext, k, curr.Priority)
+					k := state{ix1, ix2, curr.openValves}
+					upsert(qNext, &best, &pNext, k, prio)
 				}
 			}
 		}
 
-		*q = qNext
+		layer = qNext.Drain()
 	}
 
-	max := 0
-	for _, v := range best {
-		if v > max {
-			max = v
+	// every state keeps its score in the next minute, so the best score so
+	// far is the answer once the final minute is included:
+	for _, n := range layer {
+		if n.Priority > top {
+			top = n.Priority
 		}
 	}
-	return max
+	meter.Done(0, top)
+	return top, nil
 }
 
 // upsert checks to see if the given key, score combination beats what currently exists
-// within the map 'best'. If so, it will either add or update the value in the queue.
+// within the map 'best' for this minute. If so, it will either add or update the value in the queue.
 // Performs some optimisations to avoid duplicate state in the queue.
-func upsert(q *pq.Queue[state], best *map[state]int, pointers *map[state]*pq.Node[state], key state, score int) {
+func upsert(q *pq.Beam[state, int], best *map[state]int, pointers *map[state]*pq.Node[state, int], key state, score int) {
 	if key.pos2 != 0 && key.pos1 > key.pos2 {
 		key.pos1, key.pos2 = key.pos2, key.pos1
 	}
@@ -223,16 +243,11 @@ func upsert(q *pq.Queue[state], best *map[state]int, pointers *map[state]*pq.Nod
 	}
 	(*best)[key] = score
 
-	p, ok := (*pointers)[key]
-	if !ok {
-		p = &pq.Node[state]{
-			Value:    key,
-			Priority: score,
-		}
-		heap.Push(q, p)
+	if p, ok := (*pointers)[key]; ok && q.Update(p, key, score) {
+		return
+	}
+	if p := q.Push(key, score); p != nil {
 		(*pointers)[key] = p
-	} else {
-		q.Update(p, key, score)
 	}
 }
 
@@ -1,6 +1,8 @@
-package main
+package day16
 
 import (
+	"context"
+	"errors"
 	"os"
 	"strings"
 	"testing"
@@ -15,7 +17,13 @@ func TestPart1_sample(t *testing.T) {
 		t.FailNow()
 	}
 
-	got, want := part1(valves), 1651
+	got, err := part1(context.Background(), valves, _beamWidth)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	want := 1651
 	if got != want {
 		t.Logf("part1() = %d; want %d", got, want)
 		t.Fail()
@@ -31,13 +39,69 @@ func TestPart2_sample(t *testing.T) {
 		t.FailNow()
 	}
 
-	got, want := part2(valves), 1707
+	got, err := part2(context.Background(), valves, _beamWidth)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	want := 1707
 	if got != want {
 		t.Logf("part2() = %d; want %d", got, want)
 		t.Fail()
 	}
 }
 
+// TestBeamWidth checks that the default beam width finds the same answers as
+// a search that keeps every state.
+func TestBeamWidth(t *testing.T) {
+	t.Parallel()
+
+	valves, err := ReadValves(strings.NewReader(_sample))
+	if err != nil {
+		t.Log("error reading sample:", err)
+		t.FailNow()
+	}
+
+	for name, solve := range map[string]func(context.Context, map[ValveID]*Valve, int) (int, error){
+		"part1": part1,
+		"part2": part2,
+	} {
+		exact, err := solve(context.Background(), valves, 0)
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+		got, err := solve(context.Background(), valves, _beamWidth)
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+		if got != exact {
+			t.Logf("%s(width %d) = %d; want %d", name, _beamWidth, got, exact)
+			t.Fail()
+		}
+	}
+}
+
+func TestPart2_cancelled(t *testing.T) {
+	t.Parallel()
+
+	valves, err := ReadValves(strings.NewReader(_sample))
+	if err != nil {
+		t.Log("error reading sample:", err)
+		t.FailNow()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := part2(ctx, valves, _beamWidth); !errors.Is(err, context.Canceled) {
+		t.Logf("part2() error = %v; want %v", err, context.Canceled)
+		t.Fail()
+	}
+}
+
 func TestPart1_actual(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping long-running test in short mode")
@@ -47,7 +111,13 @@ func TestPart1_actual(t *testing.T) {
 	file, _ := os.Open("input.txt")
 	valves, _ := ReadValves(file)
 
-	got, want := part1(valves), 1659
+	got, err := part1(context.Background(), valves, _beamWidth)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	want := 1659
 	if got != want {
 		t.Logf("part1() = %d; want %d", got, want)
 		t.Fail()
@@ -63,7 +133,13 @@ func TestPart2_actual(t *testing.T) {
 	file, _ := os.Open("input.txt")
 	valves, _ := ReadValves(file)
 
-	got, want := part2(valves), 2382
+	got, err := part2(context.Background(), valves, _beamWidth)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	want := 2382
 	if got != want {
 		t.Logf("part2() = %d; want %d", got, want)
 		t.Fail()
@@ -78,7 +154,7 @@ func BenchmarkPart1(b *testing.B) {
 	b.ResetTimer()
 	var p1 int
 	for i := 0; i < b.N; i++ {
-		p1 = part1(valves)
+		p1, _ = part1(context.Background(), valves, _beamWidth)
 	}
 	_p1 = p1
 }
@@ -91,7 +167,7 @@ func BenchmarkPart2(b *testing.B) {
 	b.ResetTimer()
 	var p2 int
 	for i := 0; i < b.N; i++ {
-		p2 = part2(valves)
+		p2, _ = part2(context.Background(), valves, _beamWidth)
 	}
 	_p2 = p2
 }
@@ -1,4 +1,4 @@
-package main
+package day17
 
 // GameEvent represents a change in the state of the game.
 type GameEvent struct {
@@ -1,58 +1,83 @@
-package main
+package day17
 
 import (
 	"context"
-	"flag"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"os"
-	"os/signal"
-	"syscall"
+	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 )
 
-var _animateFlag = flag.Bool("animate", false, "run the animation")
+func init() {
+	puzzle.RegisterSample(17, _sample)
+	puzzle.Register(17,
+		func(ctx context.Context, r io.Reader) (any, error) {
+			moves, err := readJets(r)
+			if err != nil {
+				return nil, err
+			}
+			if w := puzzle.Animation(ctx); w != nil {
+				if err := animate1(ctx, moves, w); err != nil {
+					return nil, err
+				}
+			}
+			return part1(moves, puzzle.OutputDir(ctx))
+		},
+		func(_ context.Context, r io.Reader) (any, error) {
+			moves, err := readJets(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(moves), nil
+		})
+}
 
-func main() {
-	flag.Parse()
+// _sample is the example input from the puzzle description.
+const _sample = ">>><<><>><<<>><>>><<<>>><<<><<<>><>><<>>\n"
 
-	if *_animateFlag {
-		ctx := withInterrupt(context.Background())
-		animate1(ctx, _movement, os.Stdout)
-		return
+// readJets reads the pattern of jets of hot gas from the input.
+func readJets(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
 	}
 
-	start := time.Now()
-	p1 := part1(_movement, os.Stdout)
-	middle := time.Now()
-	p2 := part2(_movement, os.Stdout)
-	end := time.Now()
+	moves := strings.TrimSpace(string(data))
+	if len(moves) == 0 {
+		return "", errors.New("empty jet pattern")
+	}
+	if i := strings.IndexFunc(moves, func(r rune) bool { return r != '<' && r != '>' }); i >= 0 {
+		return "", fmt.Errorf("invalid jet %q at offset %d", moves[i], i)
+	}
 
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+	return moves, nil
 }
 
-// part1 solves part 1 of the puzzle
-func part1(moves string, w io.Writer) int {
-	ctrl := NewController(generator(0, []byte(moves)))
+// part1 solves part 1 of the puzzle.  If dir is not empty, it also saves
+// the final state of the chamber in that directory.
+func part1(moves, dir string) (int, error) {
+	ctrl := NewController([]byte(moves))
+	tick := ticking()
 
-	tick := make(chan struct{})
-
-	go func() {
-		defer close(tick)
-		for {
-			tick <- struct{}{}
-		}
-	}()
-
-	ch := ctrl.Run(context.Background(), tick, 2022)
+	events := ctrl.Run(context.Background(), tick, 2022)
 
 	buf := &Buffer{}
 	lastRowRendered := 0
 
-	var height int
-	for ev := range ch {
+	var (
+		height  int
+		saveErr error
+	)
+	for {
+		ev, err := events.Pop(context.Background())
+		if err != nil {
+			break
+		}
 		switch ev.Type {
 
 		case NewRockEvent, RockMovedEvent:
@@ -68,95 +93,85 @@ func part1(moves string, w io.Writer) int {
 
 		case GameStoppedEvent:
 			height = ev.TotalHeight
+			if dir == "" {
+				continue
+			}
 			r := buf.Reader()
 			r.Seek(0, io.SeekStart)
-			save(r, fmt.Sprintf("part1-final-%04d.log", ev.TotalRocks))
+			name := filepath.Join(dir, fmt.Sprintf("day17_part1-final-%04d.log", ev.TotalRocks))
+			saveErr = save(r, name)
 		}
 	}
-	return height
+	return height, saveErr
 }
 
-const (
-	_p2_period = 1730 // we get a repeating pattern every 1730 rocks (after about rock 300)
-	_p2_height = 2647 // 2647 height for every 1730 rocks, once the pattern starts
-)
-
 // part2 solves part 2 of the puzzle
-func part2(moves string, w io.Writer) int {
-	ctrl := NewController(generator(0, []byte(moves)))
+func part2(moves string) int {
+	ctrl := NewController([]byte(moves))
+	tick := ticking()
 
-	tick := make(chan struct{})
-
-	go func() {
-		defer close(tick)
-		for {
-			tick <- struct{}{}
-		}
-	}()
-
-	ch := ctrl.Run(context.Background(), tick, 1000000000000)
-	buf := &Buffer{}
-	lastRowRendered := 0
+	events := ctrl.Run(context.Background(), tick, 1000000000000)
 
 	var height int
-	for ev := range ch {
-		switch ev.Type {
-
-		case NewRockEvent, RockMovedEvent:
-			if ev.RowsFrom != lastRowRendered {
-				delta := lastRowRendered - ev.RowsFrom
-				lastRowRendered -= delta
-				buf.Seek(int64(-10*delta), io.SeekEnd)
-			}
-			for i := 0; i < len(ev.Rows); i++ {
-				ev.Rows[i].WriteTo(buf)
-			}
-			lastRowRendered += len(ev.Rows)
-
-		case RockStoppedEvent:
-			if ev.TotalRocks%_p2_period == 0 {
-				r := buf.Reader()
-				r.Seek(0, io.SeekStart)
-				save(r, fmt.Sprintf("part2-%06d.log", ev.TotalRocks))
-			}
-
-		case GameStoppedEvent:
+	for {
+		ev, err := events.Pop(context.Background())
+		if err != nil {
+			break
+		}
+		if ev.Type == GameStoppedEvent {
 			height = ev.TotalHeight
-			r := buf.Reader()
-			r.Seek(0, io.SeekStart)
-			save(r, fmt.Sprintf("part2-final-%04d.log", ev.TotalRocks))
 		}
 	}
 	return height
 }
 
-// animate1 animates part 1 of the puzzle
-func animate1(ctx context.Context, moves string, w io.Writer) {
-	ctrl := NewController(generator(0, []byte(moves)))
-
+// ticking returns a channel that is always ready to receive from, so that
+// the controller runs as fast as it can.
+func ticking() <-chan struct{} {
 	tick := make(chan struct{})
-	defer close(tick)
+	close(tick)
+	return tick
+}
 
+// animate1 animates part 1 of the puzzle on w, until it finishes or ctx is
+// cancelled.
+func animate1(ctx context.Context, moves string, w io.Writer) error {
+	ctrl := NewController([]byte(moves))
+
+	// the ticker stops when the animation does:
+	tickCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	tick := make(chan struct{})
 	go func() {
 		t := time.NewTicker(100 * time.Millisecond)
 		defer t.Stop()
 
 		for {
 			select {
-			case <-ctx.Done():
+			case <-tickCtx.Done():
 				return
 			case <-t.C:
-				tick <- struct{}{}
+			}
+
+			select {
+			case <-tickCtx.Done():
+				return
+			case tick <- struct{}{}:
 			}
 		}
 	}()
 
-	ch := ctrl.Run(ctx, tick, 2022)
+	events := ctrl.Run(ctx, tick, 2022)
 
 	buf := &Buffer{}
 	lastRowRendered := 0
 
-	for ev := range ch {
+	for {
+		ev, err := events.Pop(ctx)
+		if err != nil {
+			break
+		}
 		fmt.Fprintf(w, "%+v\n", ev)
 		fmt.Fprintln(w, lastRowRendered)
 		switch ev.Type {
@@ -180,29 +195,18 @@ func animate1(ctx context.Context, moves string, w io.Writer) {
 		r.Seek(0, io.SeekStart)
 		io.Copy(w, r)
 	}
+	return ctx.Err()
 }
 
-// withInterrupt wraps the given context, and will cancel it when the user
-// presses CTRL+C or when the OS signals the program to end.
-func withInterrupt(ctx context.Context) context.Context {
-	ctx, cancel := context.WithCancel(ctx)
-
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-
-	go func() {
-		<-c
-		cancel()
-	}()
-
-	return ctx
-}
-
-func save(r io.Reader, filename string) {
+// save copies everything from r into a new file with the given name.
+func save(r io.Reader, filename string) error {
 	file, err := os.Create(filename)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
 	}
-	defer file.Close()
-	io.Copy(file, r)
+	return file.Close()
 }
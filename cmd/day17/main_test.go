@@ -1,22 +1,48 @@
-package main
+package day17
 
 import (
-	"os"
 	"strings"
 	"testing"
 )
 
-const _sample = `>>><<><>><<<>><>>><<<>>><<<><<<>><>><<>>`
-
 func TestPart1(t *testing.T) {
 	t.Parallel()
-	got, want := part1(_sample, os.Stderr), 3068
-	if got != want {
+	got, err := part1(strings.TrimSpace(_sample), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 3068; got != want {
 		t.Logf("part1() = %d; want %d", got, want)
 		t.Fail()
 	}
 }
 
+func TestPart2(t *testing.T) {
+	t.Parallel()
+	got, want := part2(strings.TrimSpace(_sample)), 1514285714288
+	if got != want {
+		t.Logf("part2() = %d; want %d", got, want)
+		t.Fail()
+	}
+}
+
+func TestReadJets(t *testing.T) {
+	t.Parallel()
+
+	got, err := readJets(strings.NewReader(_sample))
+	if err != nil || got != strings.TrimSpace(_sample) {
+		t.Logf("readJets(_sample) = %q, %v", got, err)
+		t.Fail()
+	}
+
+	for _, in := range []string{"", "\n", "<>x<"} {
+		if _, err := readJets(strings.NewReader(in)); err == nil {
+			t.Logf("readJets(%q) did not return an error", in)
+			t.Fail()
+		}
+	}
+}
+
 func TestGenerator(t *testing.T) {
 	t.Parallel()
 
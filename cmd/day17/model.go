@@ -1,4 +1,4 @@
-package main
+package day17
 
 import (
 	"io"
@@ -36,6 +36,29 @@ var (
 
 var _shapes = []Shape{_dash, _plus, _corner, _bar, _square}
 
+// profile is the shape of the top surface of the rocks.  For each column,
+// it has the distance from the top of the rocks down to the highest rock
+// in that column, up to a limit of _profileDepth.
+type profile [7]int
+
+const _profileDepth = 64
+
+// profile returns the shape of the top surface of the rocks on this board.
+func (b Board) profile() profile {
+	var p profile
+	for x := range p {
+		mask := Row(1) << (6 - x)
+		p[x] = _profileDepth
+		for d := 0; d < _profileDepth && d < b.height; d++ {
+			if b.rows[b.height-1-d]&mask != 0 {
+				p[x] = d
+				break
+			}
+		}
+	}
+	return p
+}
+
 // makeShape is a utility function that helps to define shapes like the above.
 func makeShape(s string) Shape {
 	rows := strings.Split(s, "\n")
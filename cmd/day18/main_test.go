@@ -1,23 +1,13 @@
-package main
+package day18
 
 import (
 	"strings"
 	"testing"
-)
 
-var _sample = `2,2,2
-1,2,2
-3,2,2
-2,1,2
-2,3,2
-2,2,1
-2,2,3
-2,2,4
-2,2,6
-1,2,5
-3,2,5
-2,1,5
-2,3,5`
+	"github.com/nealmcc/aoc2022/pkg/bound"
+	"github.com/nealmcc/aoc2022/pkg/collection"
+	v "github.com/nealmcc/aoc2022/pkg/vector/threed"
+)
 
 func TestPart1(t *testing.T) {
 	r := strings.NewReader(_sample)
@@ -49,55 +39,48 @@ func TestPart2(t *testing.T) {
 
 func TestBoundsCheck(t *testing.T) {
 	t.Parallel()
-	bounds := map[point]int{
-		{1, 0, 0}:  3, // most positive x value is 3
-		{0, 1, 0}:  4,
-		{0, 0, 1}:  5,
-		{-1, 0, 0}: 3, // most negative x value is -3
-		{0, -1, 0}: 4,
-		{0, 0, -1}: 5,
-	}
+	bounds := bound.NewBox([]int{-3, -4, -5}, []int{3, 4, 5}, bound.Reject)
 
 	tt := []struct {
 		name string
-		in   []point
+		in   []v.Point
 		want bool
 	}{
 		{
 			name: "the origin is inside all bounds",
-			in:   []point{{}},
+			in:   []v.Point{{}},
 			want: true,
 		},
 		{
 			name: "points within positive and negative quadrants",
-			in: []point{
-				{1, 2, 1},
-				{-1, -2, -3},
+			in: []v.Point{
+				{X: 1, Y: 2, Z: 1},
+				{X: -1, Y: -2, Z: -3},
 			},
 			want: true,
 		},
 		{
 			name: "outside each positive axis",
-			in: []point{
-				{4, 1, 1},
-				{1, 5, 1},
-				{1, 1, 6},
+			in: []v.Point{
+				{X: 4, Y: 1, Z: 1},
+				{X: 1, Y: 5, Z: 1},
+				{X: 1, Y: 1, Z: 6},
 			},
 			want: false,
 		},
 		{
 			name: "outside each negative axis",
-			in: []point{
-				{-4, 1, 1},
-				{1, -5, 1},
-				{1, 1, -6},
+			in: []v.Point{
+				{X: -4, Y: 1, Z: 1},
+				{X: 1, Y: -5, Z: 1},
+				{X: 1, Y: 1, Z: -6},
 			},
 			want: false,
 		},
 		{
 			name: "exactly on a corner",
-			in: []point{
-				{-3, 4, -5},
+			in: []v.Point{
+				{X: -3, Y: 4, Z: -5},
 			},
 			want: true,
 		},
@@ -109,13 +92,49 @@ func TestBoundsCheck(t *testing.T) {
 			t.Parallel()
 
 			for _, p := range tc.in {
-				got := inbounds(bounds, p)
+				got := bounds.Contains(p.Coords())
 				if got != tc.want {
-					t.Logf("inbounds(%v, %v) = %t ; want %t",
-						bounds, tc.in, got, tc.want)
+					t.Logf("%v.Contains(%v) = %t ; want %t",
+						bounds, p, got, tc.want)
 					t.Fail()
 				}
 			}
 		})
 	}
 }
+
+// _benchShape is a porous 20x20x20 cube, which gives the flood fill
+// plenty of work to do.
+var _benchShape = func() collection.Set[v.Point] {
+	blocks := make(collection.Set[v.Point])
+	for x := 1; x <= 20; x++ {
+		for y := 1; y <= 20; y++ {
+			for z := 1; z <= 20; z++ {
+				if (7*x+13*y+17*z)%5 != 0 {
+					blocks.Add(v.Point{X: x, Y: y, Z: z})
+				}
+			}
+		}
+	}
+	return blocks
+}()
+
+var _result int // prevent the compiler from optimising away the call.
+
+func BenchmarkFloodFill(b *testing.B) {
+	b.Run("Stack", func(b *testing.B) {
+		var result int
+		for n := 0; n < b.N; n++ {
+			result = floodFill(_benchShape, &collection.Stack[v.Point]{})
+		}
+		_result = result
+	})
+
+	b.Run("SyncStack", func(b *testing.B) {
+		var result int
+		for n := 0; n < b.N; n++ {
+			result = floodFill(_benchShape, &collection.SyncStack[v.Point]{})
+		}
+		_result = result
+	})
+}
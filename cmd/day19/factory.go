@@ -1,4 +1,4 @@
-package main
+package day19
 
 import (
 	"fmt"
@@ -44,6 +44,16 @@ func (f *Factory) Tick(r ...Robot) int {
 	return sc
 }
 
+// potential scores the robots in the factory, favouring the ones that are
+// closer to opening geodes.
+func (f Factory) potential() int {
+	p := 0
+	for r := Geodebot; r >= Orebot; r-- {
+		p = p<<8 + f.bots[r]
+	}
+	return p
+}
+
 // Blueprint defines the material costs for each type of robot.
 type Blueprint [6]byte
 
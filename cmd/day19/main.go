@@ -1,157 +1,180 @@
-package main
+package day19
 
 import (
 	"bufio"
-	"container/heap"
+	"context"
 	"fmt"
 	"io"
-	"log"
-	"os"
-	"time"
 
 	pq "github.com/nealmcc/aoc2022/pkg/collection/prioqueue"
+	"github.com/nealmcc/aoc2022/pkg/progress"
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 )
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-	defer file.Close()
-
-	blueprints, err := readInput(file)
-	if err != nil {
-		log.Fatal(err.Error())
-	}
-	start := time.Now()
-
-	p1 := part1(blueprints)
-	middle := time.Now()
+func init() {
+	puzzle.RegisterSample(19, _sample)
+	puzzle.Register(19,
+		func(ctx context.Context, r io.Reader) (any, error) {
+			blueprints, err := readInput(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(ctx, blueprints, puzzle.Param(ctx, "beam", _beamWidth))
+		},
+		func(ctx context.Context, r io.Reader) (any, error) {
+			blueprints, err := readInput(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(ctx, blueprints, puzzle.Param(ctx, "beam", _beamWidth))
+		})
+}
 
-	p2 := part2(blueprints)
-	end := time.Now()
+// _sample is the example input from the puzzle description.
+const _sample = `Blueprint 1: Each ore robot costs 4 ore. Each clay robot costs 2 ore. Each obsidian robot costs 3 ore and 14 clay. Each geode robot costs 2 ore and 7 obsidian.
+Blueprint 2: Each ore robot costs 2 ore. Each clay robot costs 3 ore. Each obsidian robot costs 3 ore and 8 clay. Each geode robot costs 3 ore and 12 obsidian. `
 
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start)) // 1480
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))   // 3168
-}
+// _beamWidth is the default number of states that are kept each minute while
+// evaluating a blueprint.  The runner can change it with the "beam" param;
+// zero keeps them all.  TestBeamWidth checks that the default finds the
+// exact answer for the example.
+const _beamWidth = 1 << 14
 
-func part1(blueprints []Blueprint) int {
+func part1(ctx context.Context, blueprints []Blueprint, width int) (int, error) {
 	const limit = 24
 
 	sum := 0
 	for i, bp := range blueprints {
-		score := evaluate(bp, limit)
-		fmt.Printf("Blueprint %d: %d\n", i+1, score)
+		score, err := evaluate(ctx, bp, limit, width, true, fmt.Sprintf("blueprint %d", i+1))
+		if err != nil {
+			return 0, err
+		}
 		sum += (i + 1) * score
 	}
 
-	return sum
+	return sum, nil
 }
 
-func part2(blueprints []Blueprint) int {
+func part2(ctx context.Context, blueprints []Blueprint, width int) (int, error) {
 	const limit = 32
 
+	if len(blueprints) > 3 {
+		blueprints = blueprints[:3]
+	}
+
 	prod := 1
-	for i, bp := range blueprints[:3] {
-		score := evaluate(bp, limit)
-		fmt.Printf("Blueprint %d: %d\n", i+1, score)
+	for i, bp := range blueprints {
+		// the greedy choice of an obsidian bot does not hold for this longer
+		// time limit: it finds 54 geodes for the first sample blueprint, when
+		// 56 are possible.
+		score, err := evaluate(ctx, bp, limit, width, false, fmt.Sprintf("blueprint %d", i+1))
+		if err != nil {
+			return 0, err
+		}
 		prod *= score
 	}
 
-	return prod
+	return prod, nil
 }
 
-func evaluate(bp Blueprint, limit int) int {
-	// each round of evaluation uses a new queue.  This is the first.
-	qFirst := new(pq.Queue[Factory])
-	q := &qFirst
-	start := &pq.Node[Factory]{
-		Value:    Factory{bp: bp, bots: [4]int{1}},
-		Priority: 0,
-	}
-
-	best := make(map[Factory]int)
-	heap.Push(*q, start)
+// evaluate finds the largest number of geodes that can be opened using the
+// given blueprint within the time limit.  Each minute, only the best width
+// states are kept (or all of them, if width is zero).  If greedy is true,
+// then whenever an obsidian bot can be built, the search does not consider
+// building an ore bot or a clay bot instead.  The stage names the search in
+// progress reports.
+func evaluate(ctx context.Context, bp Blueprint, limit, width int, greedy bool, stage string) (int, error) {
+	// each minute is a layer of the search, with the best states first.
+	layer := []*pq.Node[Factory, rank]{{Value: Factory{bp: bp, bots: [4]int{1}}}}
+
+	meter, top := progress.NewMeter(ctx, stage), 0
 	for t := 1; t <= limit; t++ {
-		fmt.Println(t, (*q).Len())
-		qNext := new(pq.Queue[Factory])
-		pNext := make(map[Factory]*pq.Node[Factory])
-
-		for (*q).Len() > 0 {
-			curr := heap.Pop(*q).(*pq.Node[Factory])
+		qNext := pq.NewBeam[Factory](width, _rankOrder)
+		best := make(map[Factory]int) // the best score of each state in this minute
+		pNext := make(map[Factory]*pq.Node[Factory, rank])
+
+		for i, n := range layer {
+			curr, prio := n.Value, n.Priority.Major
+			if prio > top {
+				top = prio
+			}
+			if err := meter.Expand(len(layer)-i-1+qNext.Len(), top); err != nil {
+				return 0, err
+			}
 
 			// any time we can build a geodebot, do so:
-			if curr.Value.CanAfford(Geodebot) {
+			if curr.CanAfford(Geodebot) {
 				// make a copy of the current state
-				f := curr.Value
+				f := curr
 				score := f.Tick(Geodebot)
-				upsert(qNext, &best, &pNext, f, curr.Priority+score)
+				upsert(qNext, &best, &pNext, f, prio+score)
 				// building a Geodebot will always be better than any other
 				// options - don't bother evaluating the remaining states
 				continue
 			}
 
-			// next priority: if we can build an obsidian bot, do do:
-			if curr.Value.CanAfford(Obsbot) {
-				f := curr.Value
+			// next priority: if greedy, and we can build an obsidian bot, do so:
+			if greedy && curr.CanAfford(Obsbot) {
+				f := curr
 				score := (&f).Tick(Obsbot)
-				upsert(qNext, &best, &pNext, f, curr.Priority+score)
+				upsert(qNext, &best, &pNext, f, prio+score)
 				// building an obsidian bot will always
 				// be better than building an ore bot or a clay bot,
 				// but *might* not be as good as building nothing. This would
 				// only be true if it means we will end the simulation soon,
 				// and would be able to afford a geodebot sooner if we wait
 			} else {
-				if curr.Value.CanAfford(Claybot) {
-					f := curr.Value
-					score := (&f).Tick(Claybot)
-					upsert(qNext, &best, &pNext, f, curr.Priority+score)
-				}
-
-				if curr.Value.CanAfford(Orebot) {
-					f := curr.Value
-					score := (&f).Tick(Orebot)
-					upsert(qNext, &best, &pNext, f, curr.Priority+score)
+				// otherwise, consider building each of the other robots:
+				for _, r := range [...]Robot{Obsbot, Claybot, Orebot} {
+					if curr.CanAfford(r) {
+						f := curr
+						score := (&f).Tick(r)
+						upsert(qNext, &best, &pNext, f, prio+score)
+					}
 				}
 			}
 
 			// finally, consider the option of not building anything:
-			f := curr.Value
+			f := curr
 			score := (&f).Tick()
-			upsert(qNext, &best, &pNext, f, curr.Priority+score)
+			upsert(qNext, &best, &pNext, f, prio+score)
 		}
-		*q = qNext
+		layer = qNext.Drain()
 	}
 
-	max := 0
-	for k, v := range best {
-		if v > max {
-			fmt.Println("score", v, "state", k)
-			max = v
+	// every state keeps its score in the next minute, so the best score so
+	// far is the answer once the final minute is included:
+	for _, n := range layer {
+		if n.Priority.Major > top {
+			top = n.Priority.Major
 		}
 	}
-	return max
+	meter.Done(0, top)
+	return top, nil
 }
 
+// rank orders the states in each minute of the search, so that the beam
+// keeps the best of them: the most geodes opened so far, and then the most
+// potential to open more.
+type rank = pq.Pair[int, int]
+
+var _rankOrder = pq.ThenBy(pq.MaxFirst[int], pq.MaxFirst[int])
+
 // upsert checks to see if the given key, score combination beats what currently exists
-// within the map 'best'. If so, it will either add or update the value in the queue.
+// within the map 'best' for this minute. If so, it will either add or update the value in the queue.
 // Performs some optimisations to avoid duplicate state in the queue.
-func upsert(q *pq.Queue[Factory], best *map[Factory]int, pointers *map[Factory]*pq.Node[Factory], key Factory, score int) {
+func upsert(q *pq.Beam[Factory, rank], best *map[Factory]int, pointers *map[Factory]*pq.Node[Factory, rank], key Factory, score int) {
 	if sc, ok := (*best)[key]; ok && score <= sc {
 		return
 	}
 	(*best)[key] = score
 
-	p, ok := (*pointers)[key]
-	if !ok {
-		p = &pq.Node[Factory]{
-			Value:    key,
-			Priority: score,
-		}
-		heap.Push(q, p)
+	r := rank{Major: score, Minor: key.potential()}
+	if p, ok := (*pointers)[key]; ok && q.Update(p, key, r) {
+		return
+	}
+	if p := q.Push(key, r); p != nil {
 		(*pointers)[key] = p
-	} else {
-		q.Update(p, key, score)
 	}
 }
 
@@ -1,14 +1,12 @@
-package main
+package day19
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
 )
 
-const _sample = `Blueprint 1: Each ore robot costs 4 ore. Each clay robot costs 2 ore. Each obsidian robot costs 3 ore and 14 clay. Each geode robot costs 2 ore and 7 obsidian.
-Blueprint 2: Each ore robot costs 2 ore. Each clay robot costs 3 ore. Each obsidian robot costs 3 ore and 8 clay. Each geode robot costs 3 ore and 12 obsidian. `
-
 func TestPart1_sample(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
@@ -21,13 +19,79 @@ func TestPart1_sample(t *testing.T) {
 		t.FailNow()
 	}
 
-	got, want := part1(model), 33
+	got, err := part1(context.Background(), model, _beamWidth)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	want := 33
 	if got != want {
 		t.Logf("part1() = %d; want %d", got, want)
 		t.Fail()
 	}
 }
 
+func TestPart2_sample(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	t.Parallel()
+
+	model, err := readInput(strings.NewReader(_sample))
+	if err != nil {
+		t.Log("error reading sample:", err)
+		t.FailNow()
+	}
+
+	got, err := part2(context.Background(), model, _beamWidth)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	want := 56 * 62
+	if got != want {
+		t.Logf("part2() = %d; want %d", got, want)
+		t.Fail()
+	}
+}
+
+// TestBeamWidth checks that the default beam width finds the same number of
+// geodes as a search that keeps every state, for each sample blueprint.
+// Keeping every state for the 32 minutes of part 2 takes too long, so that
+// part relies on the answer given by the puzzle description instead.
+func TestBeamWidth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	t.Parallel()
+
+	model, err := readInput(strings.NewReader(_sample))
+	if err != nil {
+		t.Log("error reading sample:", err)
+		t.FailNow()
+	}
+
+	const limit = 24
+	for i, bp := range model {
+		exact, err := evaluate(context.Background(), bp, limit, 0, true, "exact")
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+		got, err := evaluate(context.Background(), bp, limit, _beamWidth, true, "beam")
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+		if got != exact {
+			t.Logf("blueprint %d: evaluate(width %d) = %d; want %d", i+1, _beamWidth, got, exact)
+			t.Fail()
+		}
+	}
+}
+
 func TestPart1_actual(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
@@ -46,7 +110,13 @@ func TestPart1_actual(t *testing.T) {
 		t.FailNow()
 	}
 
-	got, want := part1(model), 1480
+	got, err := part1(context.Background(), model, _beamWidth)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	want := 1480
 	if got != want {
 		t.Logf("part1() = %d; want %d", got, want)
 		t.Fail()
@@ -61,7 +131,7 @@ func BenchmarkPart1(b *testing.B) {
 	b.ResetTimer()
 	var p1 int
 	for i := 0; i < b.N; i++ {
-		p1 = part1(model)
+		p1, _ = part1(context.Background(), model, _beamWidth)
 	}
 	_p1 = p1
 }
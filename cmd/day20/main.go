@@ -1,41 +1,44 @@
-package main
+package day20
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"strconv"
-	"time"
-)
-
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer func() { file.Close() }()
-
-	nums, err := parseInts(file)
-	if err != nil {
-		log.Fatalf("parse: %d", err)
-	}
 
-	start := time.Now()
-	in := make([]int, len(nums))
-	copy(in, nums)
-
-	p1 := part1(in)
-	middle := time.Now()
-
-	p2 := part2(in)
-	end := time.Now()
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+)
 
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+func init() {
+	puzzle.RegisterSample(20, _sample)
+	puzzle.Register(20,
+		func(_ context.Context, r io.Reader) (any, error) {
+			nums, err := parseInts(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(nums), nil
+		},
+		func(_ context.Context, r io.Reader) (any, error) {
+			nums, err := parseInts(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(nums), nil
+		})
 }
 
+// _sample is the example input from the puzzle description.
+const _sample = `1
+2
+-3
+3
+-2
+0
+4
+`
+
 // part1 solves part 1
 func part1(nums []int) int {
 	list := NewList(nums, 0)
@@ -1,4 +1,4 @@
-package main
+package day20
 
 import (
 	"testing"
@@ -6,10 +6,10 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-var _sample = []int{1, 2, -3, 3, -2, 0, 4}
+var _sampleNums = []int{1, 2, -3, 3, -2, 0, 4}
 
 func TestPart1(t *testing.T) {
-	got, want := part1(_sample), 3
+	got, want := part1(_sampleNums), 3
 	if got != want {
 		t.Logf("part1(sample) = %d ; want %d", got, want)
 		t.Fail()
@@ -17,16 +17,16 @@ func TestPart1(t *testing.T) {
 }
 
 func TestNewList(t *testing.T) {
-	list := NewList(_sample, 0)
+	list := NewList(_sampleNums, 0)
 
-	if list.Len() != len(_sample) {
-		t.Logf("got list.Len() = %d; want %d", list.Len(), len(_sample))
+	if list.Len() != len(_sampleNums) {
+		t.Logf("got list.Len() = %d; want %d", list.Len(), len(_sampleNums))
 		t.Fail()
 	}
 
 	got := list.Root.Len()
-	if got != len(_sample) {
-		t.Logf("got list.Root.Len() = %d; want %d", got, len(_sample))
+	if got != len(_sampleNums) {
+		t.Logf("got list.Root.Len() = %d; want %d", got, len(_sampleNums))
 		t.Fail()
 	}
 
@@ -38,11 +38,11 @@ func TestNewList(t *testing.T) {
 		t.Fail()
 	}
 
-	assert.Equal(t, _sample, list.Fixed())
+	assert.Equal(t, _sampleNums, list.Fixed())
 }
 
 func TestMix(t *testing.T) {
-	list := NewList(_sample, 0)
+	list := NewList(_sampleNums, 0)
 	list.Mix()
 
 	got := list.Normal()
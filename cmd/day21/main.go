@@ -1,53 +1,41 @@
-package main
+package day21
 
 import (
 	"bufio"
+	"context"
+	_ "embed"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"regexp"
 	"strconv"
-	"time"
-)
-
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer func() { file.Close() }()
-
-	start := time.Now()
-	tree, err := parsetree(file)
-	if err != nil {
-		log.Fatalf("parse: %d", err)
-	}
-
-	p1, err := part1(tree, "root")
-	if err != nil {
-		log.Fatal("p1: ", err)
-	}
 
-	middle := time.Now()
-
-	file.Seek(0, io.SeekStart)
-	tree, err = parsetree(file)
-	if err != nil {
-		log.Fatal("parse: ", err)
-	}
-
-	p2, err := part2(tree)
-	if err != nil {
-		log.Fatalf("part2: %d", err)
-	}
-
-	end := time.Now()
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
+)
 
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+func init() {
+	puzzle.RegisterSample(21, _sample)
+	puzzle.Register(21,
+		func(_ context.Context, r io.Reader) (any, error) {
+			tree, err := parsetree(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(tree, "root")
+		},
+		func(_ context.Context, r io.Reader) (any, error) {
+			tree, err := parsetree(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(tree)
+		})
 }
 
+// _sample is the example input from the puzzle description.
+//
+//go:embed sample.txt
+var _sample string
+
 type Tree map[string][]string
 
 // part1 solves the given equation from the root node
@@ -0,0 +1,170 @@
+package day22
+
+import (
+	"fmt"
+
+	"github.com/nealmcc/aoc2022/pkg/vector/threed"
+	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
+)
+
+// Cube is the forest folded into a cube.  Each face of the cube is a
+// square section of the map, which is size units wide.
+type Cube struct {
+	grid  map[v.Point]byte
+	size  int
+	faces map[v.Point]*face // keyed by the position of the face in the net
+}
+
+// face is one face of the cube, with its orientation in three dimensions.
+type face struct {
+	origin v.Point      // the top left corner of the face on the map
+	right  threed.Point // the direction of moving East on this face
+	down   threed.Point // the direction of moving South on this face
+	normal threed.Point // the direction that this face looks out from the cube
+}
+
+// Fold the forest into a cube.  The map must be one of the 11 nets of a cube,
+// with faces of any size.
+func (f Forest) Fold() (*Cube, error) {
+	size := 0
+	for size*size*6 < len(f.grid) {
+		size++
+	}
+	if size == 0 || size*size*6 != len(f.grid) {
+		return nil, fmt.Errorf("%d tiles cannot be folded into a cube", len(f.grid))
+	}
+
+	c := &Cube{
+		grid:  f.grid,
+		size:  size,
+		faces: make(map[v.Point]*face, 6),
+	}
+
+	for y := 0; y < f.height; y += size {
+		for x := 0; x < f.width; x += size {
+			if _, ok := f.grid[v.Point{X: x, Y: y}]; ok {
+				c.faces[v.Point{X: x / size, Y: y / size}] = &face{
+					origin: v.Point{X: x, Y: y},
+				}
+			}
+		}
+	}
+	if len(c.faces) != 6 {
+		return nil, fmt.Errorf("found %d faces of size %d; want 6", len(c.faces), size)
+	}
+
+	// walk the net from the first face, folding each neighbour as we go:
+	start := c.faceAt(f.Origin())
+	start.right, start.down = threed.Point{X: 1}, threed.Point{Y: 1}
+	start.normal = start.right.Cross(start.down)
+	visited := map[*face]bool{start: true}
+	queue := []*face{start}
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+
+		for _, dir := range _facings {
+			next := c.faceAt(curr.origin.Add(dir.Vector().Times(size)))
+			if next == nil || visited[next] {
+				continue
+			}
+			// folding over an edge turns the direction across that edge
+			// towards the inside of the cube; the other direction is unchanged:
+			next.right, next.down = curr.right, curr.down
+			switch dir {
+			case v.East:
+				next.right = curr.normal.Times(-1)
+			case v.South:
+				next.down = curr.normal.Times(-1)
+			case v.West:
+				next.right = curr.normal
+			case v.North:
+				next.down = curr.normal
+			}
+			next.normal = next.right.Cross(next.down)
+			visited[next] = true
+			queue = append(queue, next)
+		}
+	}
+
+	normals := make(map[threed.Point]bool, 6)
+	for _, fc := range c.faces {
+		normals[fc.normal] = true
+	}
+	if len(normals) != 6 {
+		return nil, fmt.Errorf("the map is not the net of a cube")
+	}
+
+	return c, nil
+}
+
+// faceAt returns the face that contains the given point on the map.
+func (c *Cube) faceAt(p v.Point) *face {
+	return c.faces[v.Point{X: p.X / c.size, Y: p.Y / c.size}]
+}
+
+// faceWithNormal returns the face that looks out in the given direction.
+func (c *Cube) faceWithNormal(n threed.Point) *face {
+	for _, fc := range c.faces {
+		if fc.normal == n {
+			return fc
+		}
+	}
+	panic(fmt.Sprintf("no face with normal %v", n))
+}
+
+// vector returns the direction in three dimensions of moving in the given
+// direction on this face.
+func (fc *face) vector(dir v.Direction) threed.Point {
+	return [...]threed.Point{fc.right, fc.down, fc.right.Times(-1), fc.down.Times(-1)}[facingScore(dir)]
+}
+
+// facing returns the direction on this face that points along the given
+// vector.  The vector must be parallel to this face.
+func (fc *face) facing(u threed.Point) v.Direction {
+	for _, dir := range _facings {
+		if fc.vector(dir) == u {
+			return dir
+		}
+	}
+	panic(fmt.Sprintf("%v is not parallel to the face at %v", u, fc.origin))
+}
+
+// wrap implements wrapFunc: it takes one step on the surface of the cube,
+// moving over the edge onto the adjoining face if necessary.
+func (c *Cube) wrap(pos v.Point, dir v.Direction) (v.Point, v.Direction) {
+	next := pos.Add(dir.Vector())
+	if _, ok := c.grid[next]; ok {
+		return next, dir
+	}
+
+	from := c.faceAt(pos)
+	to := c.faceWithNormal(from.vector(dir))
+
+	// the edge of the next face that touches this one, and how far along
+	// that edge we are (going clockwise around each face):
+	edge := to.facing(from.normal)
+	offset := c.size - 1 - c.clockwise(pos.Sub(from.origin), dir)
+
+	n := c.size - 1
+	var local v.Point
+	switch edge {
+	case v.East:
+		local = v.Point{X: n, Y: offset}
+	case v.South:
+		local = v.Point{X: n - offset, Y: n}
+	case v.West:
+		local = v.Point{X: 0, Y: n - offset}
+	case v.North:
+		local = v.Point{X: offset, Y: 0}
+	}
+
+	return to.origin.Add(local), edge.Opposite()
+}
+
+// clockwise returns how far along the given edge of a face the point is,
+// going clockwise around the face.  The point is relative to the face's origin.
+func (c *Cube) clockwise(local v.Point, edge v.Direction) int {
+	n := c.size - 1
+	return [...]int{local.Y, n - local.X, n - local.Y, local.X}[facingScore(edge)]
+}
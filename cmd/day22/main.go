@@ -1,89 +1,106 @@
-package main
+package day22
 
 import (
 	"bufio"
+	"context"
+	_ "embed"
 	"fmt"
 	"io"
-	"log"
 	"os"
 	"regexp"
 	"strconv"
-	"time"
 
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 )
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer func() { file.Close() }()
-
-	start := time.Now()
-	forest, path, err := parseInput(file)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	p1 := part1(forest, path)
-	middle := time.Now()
-
-	p2 := part2(forest, path)
-	end := time.Now()
-
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+func init() {
+	puzzle.RegisterSample(22, _sample)
+	puzzle.Register(22,
+		func(_ context.Context, r io.Reader) (any, error) {
+			forest, path, err := parseInput(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(forest, path), nil
+		},
+		func(_ context.Context, r io.Reader) (any, error) {
+			forest, path, err := parseInput(r)
+			if err != nil {
+				return nil, err
+			}
+			return part2(forest, path)
+		})
 }
 
-func part1(f Forest, path []Step) int {
+// _sample is the example input from the puzzle description.
+//
+//go:embed sample.txt
+var _sample string
+
+func part1(f Forest, path []Step, verbose ...bool) int {
+	f.debug = len(verbose) > 0 && verbose[0]
 	curr := f.Origin()
-	dir := Right
+	dir := v.East
 
 	for _, s := range path {
 		if s.Rotation == 0 {
-			fmt.Printf("walking %d %s\n", s.Dist, dir)
+			if f.debug {
+				fmt.Fprintf(os.Stderr, "walking %d %s\n", s.Dist, dir)
+			}
 			curr, dir = f.Next(curr, s.Dist, dir, f.wrap1)
 			continue
 		}
 
-		var next Facing
 		if s.Rotation == 'L' {
-			next = ((dir - 1) + 4) % 4
+			dir = dir.TurnLeft()
 		} else {
-			next = (dir + 1) % 4
+			dir = dir.TurnRight()
+		}
+		if f.debug {
+			fmt.Fprintf(os.Stderr, "turned %c ; standing at %s facing %s\n",
+				s.Rotation, curr, dir)
 		}
-		dir = next
-		fmt.Printf("turned %c ; standing at %s facing %s\n",
-			s.Rotation, curr, dir)
 	}
-	fmt.Printf("standing at %s facing %s\n", curr, dir)
-	return 1000*(curr.Y+1) + 4*(curr.X+1) + int(dir)
+	if f.debug {
+		fmt.Fprintf(os.Stderr, "standing at %s facing %s\n", curr, dir)
+	}
+	return 1000*(curr.Y+1) + 4*(curr.X+1) + facingScore(dir)
 }
 
-func part2(f Forest, path []Step) int {
+func part2(f Forest, path []Step, verbose ...bool) (int, error) {
+	f.debug = len(verbose) > 0 && verbose[0]
+	cube, err := f.Fold()
+	if err != nil {
+		return 0, err
+	}
+
 	curr := f.Origin()
-	dir := Right
+	dir := v.East
 
 	for _, s := range path {
 		if s.Rotation == 0 {
-			fmt.Printf("walking %d %s\n", s.Dist, dir)
-			curr, dir = f.Next(curr, s.Dist, dir, f.wrap2)
+			if f.debug {
+				fmt.Fprintf(os.Stderr, "walking %d %s\n", s.Dist, dir)
+			}
+			curr, dir = f.Next(curr, s.Dist, dir, cube.wrap)
 			continue
 		}
 
-		var next Facing
 		if s.Rotation == 'L' {
-			next = ((dir - 1) + 4) % 4
+			dir = dir.TurnLeft()
 		} else {
-			next = (dir + 1) % 4
+			dir = dir.TurnRight()
 		}
-		dir = next
-		fmt.Printf("turned %c ; standing at %s facing %s\n",
-			s.Rotation, curr, dir)
+		if f.debug {
+			fmt.Fprintf(os.Stderr, "turned %c ; standing at %s facing %s\n",
+				s.Rotation, curr, dir)
+		}
+	}
+	if f.debug {
+		fmt.Fprintf(os.Stderr, "standing at %s facing %s\n", curr, dir)
 	}
-	fmt.Printf("standing at %s facing %s\n", curr, dir)
-	return 1000*(curr.Y+1) + 4*(curr.X+1) + int(dir)
+	return 1000*(curr.Y+1) + 4*(curr.X+1) + facingScore(dir), nil
 }
 
 func parseInput(r io.Reader) (Forest, []Step, error) {
@@ -1,10 +1,11 @@
-package main
+package day22
 
 import (
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/nealmcc/aoc2022/pkg/bound"
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 	"github.com/stretchr/testify/assert"
 )
@@ -36,8 +37,8 @@ func TestParseForest(t *testing.T) {
 		in     string
 		origin v.Point
 		grid   map[v.Point]byte
-		horiz  []Bound
-		vert   []Bound
+		horiz  []bound.Linear
+		vert   []bound.Linear
 	}{
 		{
 			name: "basic 3x3 square forest",
@@ -45,12 +46,12 @@ func TestParseForest(t *testing.T) {
 .##
 ###`,
 			origin: v.Point{X: 0, Y: 0},
-			horiz: []Bound{
+			horiz: []bound.Linear{
 				{Min: 0, Max: 2},
 				{Min: 0, Max: 2},
 				{Min: 0, Max: 2},
 			},
-			vert: []Bound{
+			vert: []bound.Linear{
 				{Min: 0, Max: 2},
 				{Min: 0, Max: 2},
 				{Min: 0, Max: 2},
@@ -76,7 +77,7 @@ func TestParseForest(t *testing.T) {
 					        .#......
 					        ......#.`,
 			origin: v.Point{X: 8, Y: 0},
-			horiz: []Bound{
+			horiz: []bound.Linear{
 				// top section
 				{Min: 8, Max: 11},
 				{Min: 8, Max: 11},
@@ -93,7 +94,7 @@ func TestParseForest(t *testing.T) {
 				{Min: 8, Max: 15},
 				{Min: 8, Max: 15},
 			},
-			vert: []Bound{
+			vert: []bound.Linear{
 				// left section
 				{Min: 4, Max: 7},
 				{Min: 4, Max: 7},
@@ -168,3 +169,92 @@ func TestParseForest(t *testing.T) {
 		})
 	}
 }
+
+func TestPart2(t *testing.T) {
+	forest, path, err := parseInput(strings.NewReader(_sample))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	got, err := part2(forest, path)
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+
+	if want := 5031; got != want {
+		t.Logf("part2(sample) = %d ; want %d", got, want)
+		t.Fail()
+	}
+}
+
+func TestFold(t *testing.T) {
+	t.Parallel()
+
+	// each net is drawn with one character per face.
+	tt := []struct {
+		name string
+		net  string
+		size int
+	}{
+		{name: "sample", net: "..#\n###\n..##", size: 4},
+		{name: "my input", net: ".##\n.#\n##\n#", size: 3},
+		{name: "cross", net: ".#\n###\n.#\n.#", size: 2},
+		{name: "stairs", net: "#\n##\n.##\n..#", size: 3},
+		{name: "two rows", net: "###\n..###", size: 1},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := Forest{grid: make(map[v.Point]byte)}
+			for fy, row := range strings.Split(tc.net, "\n") {
+				for fx, ch := range row {
+					if ch != '#' {
+						continue
+					}
+					for y := 0; y < tc.size; y++ {
+						for x := 0; x < tc.size; x++ {
+							p := v.Point{X: fx*tc.size + x, Y: fy*tc.size + y}
+							f.grid[p] = '.'
+							if p.X >= f.width {
+								f.width = p.X + 1
+							}
+							if p.Y >= f.height {
+								f.height = p.Y + 1
+							}
+						}
+					}
+				}
+			}
+			f.setBounds()
+
+			cube, err := f.Fold()
+			if err != nil {
+				t.Log(err)
+				t.FailNow()
+			}
+
+			// walking in a straight line around the cube brings us back to
+			// where we started, facing the same way:
+			for p := range f.grid {
+				for _, dir := range _facings {
+					pos, facing := p, dir
+					for i := 0; i < 4*tc.size; i++ {
+						pos, facing = cube.wrap(pos, facing)
+					}
+					if pos != p || facing != dir {
+						t.Logf("from %v facing %s: got to %v facing %s", p, dir, pos, facing)
+						t.FailNow()
+					}
+				}
+			}
+		})
+	}
+
+	_, err := Forest{grid: map[v.Point]byte{{}: '.', {X: 1}: '.'}, width: 2, height: 1}.Fold()
+	assert.Error(t, err)
+}
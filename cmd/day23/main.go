@@ -1,39 +1,43 @@
-package main
+package day23
 
 import (
 	"bufio"
+	"context"
+	_ "embed"
 	"fmt"
 	"io"
-	"log"
-	"os"
-	"time"
 
+	"github.com/nealmcc/aoc2022/pkg/collection"
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 )
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer func() { file.Close() }()
-
-	start := time.Now()
-	forest, err := parseInput(file)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	p1 := part1(&forest)
-	middle := time.Now()
-
-	p2 := part2(&forest)
-	end := time.Now()
-
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+func init() {
+	puzzle.RegisterSample(23, _sample)
+	puzzle.Register(23,
+		func(_ context.Context, r io.Reader) (any, error) {
+			forest, err := parseInput(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(&forest), nil
+		},
+		func(_ context.Context, r io.Reader) (any, error) {
+			forest, err := parseInput(r)
+			if err != nil {
+				return nil, err
+			}
+			// part 2 continues on from where part 1 finished.
+			part1(&forest)
+			return part2(&forest), nil
+		})
 }
 
+// _sample is the example input from the puzzle description.
+//
+//go:embed sample.txt
+var _sample string
+
 func part1(f *Forest) int {
 	for i := 0; i < 10; i++ {
 		f.Tick(dirSequence(i))
@@ -58,7 +62,7 @@ func parseInput(r io.Reader) (Forest, error) {
 	s := bufio.NewScanner(r)
 
 	f := Forest{
-		Grid: make(map[Elf]struct{}, 70*70),
+		Grid: make(collection.Set[Elf], 70*70),
 	}
 
 	var x, y int
@@ -69,7 +73,7 @@ func parseInput(r io.Reader) (Forest, error) {
 		for i := 0; i < len(b); i, x = i+1, x+1 {
 			switch b[i] {
 			case '#':
-				f.Grid[Elf{v.Point{X: x, Y: y}}] = struct{}{}
+				f.Grid.Add(Elf{v.Point{X: x, Y: y}})
 
 			case '.', ' ':
 				continue
@@ -1,17 +1,18 @@
-package main
+package day23
 
 import (
 	"bytes"
 	"fmt"
 
 	"github.com/nealmcc/aoc2022/pkg/bound"
+	"github.com/nealmcc/aoc2022/pkg/collection"
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 )
 
 // Forest is a two dimensional grid containing elves
 type Forest struct {
 	extents bound.Rect
-	Grid    map[Elf]struct{}
+	Grid    collection.Set[Elf]
 }
 
 // Elf is a v.Point with additional methods to look adjacent to itself.
@@ -22,27 +23,27 @@ type Elf struct {
 // Tick processes a full round of elf diffusion
 // The given facings determine the sequence of directions that the elves will
 // use during this round when looking for a destination.
-func (f *Forest) Tick(dirs [4]Facing) bool {
-	next := make(map[Elf]struct{}, len(f.Grid))
+func (f *Forest) Tick(dirs [4]v.Direction) bool {
+	next := make(collection.Set[Elf], f.Grid.Len())
 	// proposals maps from a destination to a list of elves that want to move there.
 	proposals := make(map[v.Point][]Elf)
 	for elf := range f.Grid {
 		if elf.IsAlone(*f) {
-			next[elf] = struct{}{}
+			next.Add(elf)
 			continue
 		}
 		if dest, ok := elf.Survey(*f, dirs); ok {
 			proposals[dest] = append(proposals[dest], elf)
 		} else {
-			next[elf] = struct{}{}
+			next.Add(elf)
 		}
 	}
 	for p, list := range proposals {
 		if len(list) == 1 {
-			next[Elf{p}] = struct{}{}
+			next.Add(Elf{p})
 		} else {
 			for _, elf := range list {
-				next[elf] = struct{}{}
+				next.Add(elf)
 			}
 		}
 	}
@@ -51,44 +52,16 @@ func (f *Forest) Tick(dirs [4]Facing) bool {
 }
 
 func (f *Forest) setBounds() {
-	const large = 1<<63 - 1
-
-	b := bound.Rect{
-		Min: v.Point{X: large, Y: large},
-		Max: v.Point{X: -1 * large, Y: -1 * large},
-	}
-
-	for p := range f.Grid {
-		if p.X < b.Min.X {
-			b.Min.X = p.X
-		}
-		if p.X > b.Max.X {
-			b.Max.X = p.X
-		}
-
-		if p.Y < b.Min.Y {
-			b.Min.Y = p.Y
-		}
-		if p.Y > b.Max.Y {
-			b.Max.Y = p.Y
-		}
+	b := bound.FromPoints()
+	for elf := range f.Grid {
+		b = b.Expand(elf.Point)
 	}
 	f.extents = b
 }
 
 func (f Forest) CountEmpty() int {
-	count := 0
 	f.setBounds()
-	min := f.extents.Min
-	max := f.extents.Max
-	for x := min.X; x <= max.X; x++ {
-		for y := min.Y; y <= max.Y; y++ {
-			if _, ok := f.Grid[Elf{v.Point{X: x, Y: y}}]; !ok {
-				count++
-			}
-		}
-	}
-	return count
+	return f.extents.Area() - f.Grid.Len()
 }
 
 // Format implements fmt.Formatter.
@@ -109,7 +82,7 @@ func (f Forest) Format(s fmt.State, verb rune) {
 	for row, y := 0, -1*pad+f.extents.Min.Y; row < height; row, y = row+1, y+1 {
 		buf := make([]byte, width)
 		for col, x := 0, -1*pad+f.extents.Min.X; col < width; col, x = col+1, x+1 {
-			if _, ok := f.Grid[Elf{v.Point{X: x, Y: y}}]; ok {
+			if f.Grid.Contains(Elf{v.Point{X: x, Y: y}}) {
 				buf[col] = '#'
 			} else {
 				buf[col] = '.'
@@ -127,37 +100,14 @@ func (f Forest) String() string {
 	return fmt.Sprintf("%v", f)
 }
 
-type Facing int
-
-const (
-	North Facing = iota
-	South
-	West
-	East
-)
-
-func (f Facing) String() string {
-	return [...]string{
-		"N",
-		"S",
-		"W",
-		"E",
-	}[f]
-}
+// _lookOrder is the order in which the elves look around them in the first
+// round.  Each round, the first direction moves to the end of the list.
+var _lookOrder = [4]v.Direction{v.North, v.South, v.West, v.East}
 
-func (f Facing) AsVector() v.Point {
-	return [...]v.Point{
-		{Y: -1},
-		{Y: 1},
-		{X: -1},
-		{X: 1},
-	}[f]
-}
-
-func dirSequence(n int) [4]Facing {
-	res := [4]Facing{}
+func dirSequence(n int) [4]v.Direction {
+	res := [4]v.Direction{}
 	for i := 0; i < 4; i++ {
-		res[i] = Facing((n + i) % 4)
+		res[i] = _lookOrder[(n+i)%4]
 	}
 	return res
 }
@@ -165,7 +115,7 @@ func dirSequence(n int) [4]Facing {
 // IsAlone asks the elf if there are any other elves beside it.
 func (e Elf) IsAlone(f Forest) bool {
 	for _, p := range e.Neighbours8() {
-		if _, ok := f.Grid[Elf{p}]; ok {
+		if f.Grid.Contains(Elf{p}) {
 			return false
 		}
 	}
@@ -175,44 +125,26 @@ func (e Elf) IsAlone(f Forest) bool {
 // Survey asks the elf to look around it in each direction in turn and look
 // for a good place to move to. If the elf sees a good place, it returns it.
 // If there is no suitable place then the elf returns false.
-func (e Elf) Survey(f Forest, dirs [4]Facing) (v.Point, bool) {
+func (e Elf) Survey(f Forest, dirs [4]v.Direction) (v.Point, bool) {
 dirloop:
 	for _, d := range dirs {
 		for _, p := range e.Adjacent3(d) {
-			if _, isFull := f.Grid[Elf{p}]; isFull {
+			if f.Grid.Contains(Elf{p}) {
 				continue dirloop
 			}
 		}
-		next := e.Add(d.AsVector())
+		next := e.Add(d.Vector())
 		return next, true
 	}
 	return e.Point, false
 }
 
-// Neighbours8 returns the eight points adjacent to this elf.
-func (e Elf) Neighbours8() [8]v.Point {
-	return [8]v.Point{
-		e.add(-1, -1), e.add(0, -1), e.add(1, -1),
-		e.add(-1, 0), e.add(1, 0),
-		e.add(-1, 1), e.add(0, 1), e.add(1, 1),
+// Adjacent3 returns the three points adjacent to this elf in the given
+// direction: straight ahead, and diagonally to either side.
+func (e Elf) Adjacent3(dir v.Direction) [3]v.Point {
+	return [3]v.Point{
+		e.Add(dir.Rotate(-1).Vector()),
+		e.Add(dir.Vector()),
+		e.Add(dir.Rotate(1).Vector()),
 	}
 }
-
-// Adjacent3 returns the three points adjacent to this elf in the given direction
-func (e Elf) Adjacent3(dir Facing) [3]v.Point {
-	return [...][3]v.Point{
-		// North
-		{e.add(-1, -1), e.add(0, -1), e.add(1, -1)},
-		// South
-		{e.add(-1, 1), e.add(0, 1), e.add(1, 1)},
-		// West
-		{e.add(-1, -1), e.add(-1, 0), e.add(-1, 1)},
-		// East
-		{e.add(1, -1), e.add(1, 0), e.add(1, 1)},
-	}[dir]
-}
-
-// add is a utility function that makes it easier to read
-func (e Elf) add(x, y int) v.Point {
-	return e.Add(v.Point{X: x, Y: y})
-}
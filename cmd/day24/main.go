@@ -1,49 +1,51 @@
-package main
+package day24
 
 import (
 	"bufio"
 	"bytes"
-	"container/heap"
+	"context"
+	_ "embed"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"os"
-	"time"
 
+	"github.com/nealmcc/aoc2022/pkg/bound"
 	pq "github.com/nealmcc/aoc2022/pkg/collection/prioqueue"
+	"github.com/nealmcc/aoc2022/pkg/progress"
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 )
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer func() { file.Close() }()
-
-	start := time.Now()
-	storm, err := parse(file)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	p1, err := part1(storm)
-	if err != nil {
-		log.Fatal(err)
-	}
-	middle := time.Now()
-
-	p2, err := part2(storm, p1, false)
-	if err != nil {
-		log.Fatal(err)
-	}
-	end := time.Now()
-
-	fmt.Printf("part 1: %d in %s\n", p1, middle.Sub(start))
-	fmt.Printf("part 2: %d in %s\n", p2, end.Sub(middle))
+func init() {
+	puzzle.RegisterSample(24, _sample)
+	puzzle.Register(24,
+		func(ctx context.Context, r io.Reader) (any, error) {
+			storm, err := parse(r)
+			if err != nil {
+				return nil, err
+			}
+			return part1(ctx, storm)
+		},
+		func(ctx context.Context, r io.Reader) (any, error) {
+			storm, err := parse(r)
+			if err != nil {
+				return nil, err
+			}
+			// part 2 continues on from where part 1 finished.
+			p1, err := part1(ctx, storm)
+			if err != nil {
+				return nil, err
+			}
+			return part2(ctx, storm, p1, false)
+		})
 }
 
+// _sample is the second (larger) example input from the puzzle description.
+//
+//go:embed sample2.txt
+var _sample string
+
 // State is a current state of the grid. It combines the current position with
 // the positions of all the ice in the storm.
 //
@@ -57,20 +59,20 @@ type State struct {
 
 // part1 finds the fewest number of turns to travel through the storm.
 // Uses the A* search algorithm.
-func part1(st Storm) (int, error) {
-	return solve(st, 0)
+func part1(ctx context.Context, st Storm) (int, error) {
+	return solve(ctx, st, 0)
 }
 
-func part2(st Storm, p1 int, verbose bool) (int, error) {
+func part2(ctx context.Context, st Storm, p1 int, verbose bool) (int, error) {
 	st.start, st.end = st.end, st.start
-	cost, err := solve(st, p1, verbose)
+	cost, err := solve(ctx, st, p1, verbose)
 	if err != nil {
 		return 0, err
 	}
 
 	sum := p1 + cost
 	st.start, st.end = st.end, st.start
-	cost, err = solve(st, sum, verbose)
+	cost, err = solve(ctx, st, sum, verbose)
 	if err != nil {
 		return 0, err
 	}
@@ -79,7 +81,7 @@ func part2(st Storm, p1 int, verbose bool) (int, error) {
 	return sum, nil
 }
 
-func solve(storm Storm, startTime int, verbose ...bool) (int, error) {
+func solve(ctx context.Context, storm Storm, startTime int, verbose ...bool) (int, error) {
 	debug := len(verbose) > 0 && verbose[0]
 
 	var (
@@ -87,36 +89,36 @@ func solve(storm Storm, startTime int, verbose ...bool) (int, error) {
 			Point:   storm.start,
 			iceHash: startTime,
 		}: 0} // known exact costs for each state
-		visited  = make(map[State]bool, 100)       // states we are finished with
-		q        = new(pq.Queue[State])            // next states to examine
-		pointers = make(map[State]*pq.Node[State]) // pointers to those states
-		path     = make(map[State]State)           // the previous node
+		visited = make(map[State]bool, 100)  // states we are finished with
+		q       = pq.NewKeyed[State](_order) // next states to examine
+		path    = make(map[State]State)      // the previous node
 	)
 
-	heap.Push(q, &pq.Node[State]{
-		Value: State{Point: storm.start},
-	})
+	q.PushOrUpdate(State{Point: storm.start, iceHash: startTime}, pq.Pair[int, int]{})
+	meter := progress.NewMeter(ctx, fmt.Sprintf("from %v at t=%d", storm.start, startTime))
 	for q.Len() > 0 {
-		node := heap.Pop(q).(*pq.Node[State])
-		keyCurr := node.Value
-		delete(pointers, keyCurr)
+		keyCurr, prioCurr, _ := q.Pop()
 
 		visited[keyCurr] = true
 		costCurr := cost[keyCurr]
+		if err := meter.Expand(q.Len(), costCurr); err != nil {
+			return 0, err
+		}
 
 		if debug {
-			fmt.Printf("\n== priority %d ==\n\tarrived at %v from %v at time t=%d (+%d)\n",
-				node.Priority, keyCurr, path[keyCurr], costCurr, startTime)
+			fmt.Fprintf(os.Stderr, "\n== priority %d ==\n\tarrived at %v from %v at time t=%d (+%d)\n",
+				prioCurr.Major, keyCurr, path[keyCurr], costCurr, startTime)
 
 			buf := storm.At(startTime + costCurr).Render()
 			compose(buf, map[v.Point]byte{
 				keyCurr.Add(v.Point{X: 1, Y: 1}): 'E',
 			})
-			fmt.Println(string(bytes.Join(buf, []byte("\n"))))
+			fmt.Fprintln(os.Stderr, string(bytes.Join(buf, []byte("\n"))))
 		}
 
 		if keyCurr.Point == storm.end {
-			return cost[keyCurr], nil
+			meter.Done(q.Len(), costCurr)
+			return costCurr, nil
 		}
 
 		for _, move := range [...]Ice{South, East, None, North, West} {
@@ -128,20 +130,20 @@ func solve(storm Storm, startTime int, verbose ...bool) (int, error) {
 			}
 
 			if debug {
-				fmt.Printf("considering moving %s to %s at t = %d", move, posNext, startTime+costNext)
+				fmt.Fprintf(os.Stderr, "considering moving %s to %s at t = %d", move, posNext, startTime+costNext)
 			}
 
 			ice, ok := storm.IceAt(posNext, startTime+costNext)
 			if !ok {
 				if debug {
-					fmt.Println("; out of bounds.")
+					fmt.Fprintln(os.Stderr, "; out of bounds.")
 				}
 				continue
 			}
 
 			if ice > None {
 				if debug {
-					fmt.Println("; there will be ice.")
+					fmt.Fprintln(os.Stderr, "; there will be ice.")
 				}
 				continue
 			}
@@ -152,12 +154,12 @@ func solve(storm Storm, startTime int, verbose ...bool) (int, error) {
 			}
 
 			if debug {
-				fmt.Printf("; icehash %d ", keyNext.iceHash)
+				fmt.Fprintf(os.Stderr, "; icehash %d ", keyNext.iceHash)
 			}
 
 			if visited[keyNext] {
 				if debug {
-					fmt.Printf("; we already visited %s with icehash %d\n",
+					fmt.Fprintf(os.Stderr, "; we already visited %s with icehash %d\n",
 						keyNext, keyNext.iceHash)
 				}
 				continue
@@ -166,49 +168,49 @@ func solve(storm Storm, startTime int, verbose ...bool) (int, error) {
 			bestSoFar, ok := cost[keyNext]
 			if ok {
 				if debug {
-					fmt.Printf("; costNext: %d vs best found so far: %d", costNext, bestSoFar)
+					fmt.Fprintf(os.Stderr, "; costNext: %d vs best found so far: %d", costNext, bestSoFar)
 				}
 				if costNext >= bestSoFar {
 					if debug {
-						fmt.Println("; this not better - skipping.")
+						fmt.Fprintln(os.Stderr, "; this not better - skipping.")
 					}
 					continue
 				} else {
 					if debug {
-						fmt.Print("; this is better; ")
+						fmt.Fprint(os.Stderr, "; this is better; ")
 					}
 				}
 			} else {
 				if debug {
-					fmt.Print("; this is a new state for us")
+					fmt.Fprint(os.Stderr, "; this is a new state for us")
 				}
 			}
 
 			cost[keyNext] = costNext
 			path[keyNext] = keyCurr
 
-			prio := -1 * (costNext + v.ManhattanLength(storm.end.Sub(posNext)))
-			if p, ok := pointers[keyNext]; ok {
-				if debug {
-					fmt.Println("; updating priority to ", prio)
-				}
-				q.Update(p, keyNext, prio)
-			} else {
-				p = &pq.Node[State]{
-					Value:    keyNext,
-					Priority: prio,
-				}
-				if debug {
-					fmt.Println("; adding the state to the queue with priority ", prio)
+			prio := pq.Pair[int, int]{
+				Major: costNext + v.ManhattanLength(storm.end.Sub(posNext)),
+				Minor: costNext,
+			}
+			if debug {
+				if q.Contains(keyNext) {
+					fmt.Fprintln(os.Stderr, "; updating priority to ", prio)
+				} else {
+					fmt.Fprintln(os.Stderr, "; adding the state to the queue with priority ", prio)
 				}
-				heap.Push(q, p)
 			}
+			q.PushOrUpdate(keyNext, prio)
 		}
 	}
 
 	return 0, errors.New("no path found")
 }
 
+// _order is the order in which solve examines states: the lowest estimated
+// total cost first, and then the highest cost so far.
+var _order = pq.ThenBy(pq.MinFirst[int], pq.MaxFirst[int])
+
 func parse(r io.Reader) (Storm, error) {
 	s := bufio.NewScanner(r)
 
@@ -245,6 +247,7 @@ func parse(r io.Reader) (Storm, error) {
 	// the walls and entry / exit points are not included in the storm extents:
 	storm.extents.Max.X -= 1
 	storm.extents.Max.Y = y - 2
+	storm.wrap = storm.extents.Box(bound.Wrap)
 
 	if err := s.Err(); err != nil {
 		return Storm{}, err
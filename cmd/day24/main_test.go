@@ -1,6 +1,7 @@
-package main
+package day24
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
@@ -19,7 +20,7 @@ func TestPart1(t *testing.T) {
 		t.FailNow()
 	}
 
-	got, err := part1(storm)
+	got, err := part1(context.Background(), storm)
 	if err != nil {
 		t.Log(err)
 		t.FailNow()
@@ -45,7 +46,7 @@ func TestPart2(t *testing.T) {
 		t.FailNow()
 	}
 
-	got, err := part2(storm, 18, true)
+	got, err := part2(context.Background(), storm, 18, true)
 	if err != nil {
 		t.Log(err)
 		t.FailNow()
@@ -1,4 +1,4 @@
-package main
+package day24
 
 import (
 	"bytes"
@@ -10,6 +10,7 @@ import (
 type Storm struct {
 	grid       map[v.Point]Ice // a map of the ice at time t = 0
 	extents    bound.Rect      // the rectangle that contains the storm itself
+	wrap       bound.Box       // the same rectangle, wrapping in both directions
 	start, end v.Point         // the entry and exit points
 }
 
@@ -62,6 +63,7 @@ func compose(lines [][]byte, points map[v.Point]byte) {
 func (st Storm) At(t int) Storm {
 	storm2 := Storm{
 		extents: st.extents,
+		wrap:    st.wrap,
 		start:   st.start,
 		end:     st.end,
 		grid:    make(map[v.Point]Ice, len(st.grid)),
@@ -89,11 +91,14 @@ func (st Storm) IceAt(pos v.Point, t int) (Ice, bool) {
 
 	var result Ice
 
+	// the ice wraps around the storm in both directions:
 	for _, ice := range [...]Ice{North, East, South, West} {
 		delta := ice.AsVector().Times(-1 * t)
-		p := st.extents.Mod(pos.Add(delta))
-		incoming := st.grid[p]
-		result |= incoming & ice
+		p, ok := st.wrap.Fit2(pos.Add(delta))
+		if !ok {
+			continue
+		}
+		result |= st.grid[p] & ice
 	}
 
 	return result, true
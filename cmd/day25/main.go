@@ -1,39 +1,47 @@
-package main
+package day25
 
 import (
 	"bufio"
-	"fmt"
-	"log"
-	"os"
-	"time"
+	"context"
+	"io"
+
+	"github.com/nealmcc/aoc2022/pkg/puzzle"
 )
 
-func main() {
-	file, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer func() { file.Close() }()
+func init() {
+	puzzle.RegisterSample(25, _sample)
+	// there is no part 2 on the last day.
+	puzzle.Register(25, func(_ context.Context, r io.Reader) (any, error) { return part1(r) })
+}
 
-	s := bufio.NewScanner(file)
-	lines := make([]string, 0, 102)
+// _sample is the example input from the puzzle description.
+const _sample = `1=-0-2
+12111
+2=0=
+21
+2=01
+111
+20012
+112
+1=-1=
+1-12
+12
+1=
+122
+`
+
+// part1 adds up all of the SNAFU numbers in the input,
+// and returns the sum as a SNAFU number.
+func part1(r io.Reader) (string, error) {
+	s := bufio.NewScanner(r)
+	sum := 0
 	for s.Scan() {
-		lines = append(lines, s.Text())
+		sum += SNAFUToInt(s.Text())
 	}
 	if err := s.Err(); err != nil {
-		log.Fatal(err)
+		return "", err
 	}
-
-	start := time.Now()
-	sum := 0
-	for _, s := range lines {
-		sum += SNAFUToInt(s)
-	}
-	p1 := IntToSNAFU(sum)
-
-	end := time.Now()
-
-	fmt.Printf("part 1: %s in %s\n", p1, end.Sub(start))
+	return IntToSNAFU(sum), nil
 }
 
 func IntToSNAFU(n int) string {
@@ -3,12 +3,18 @@ package bound
 
 import v "github.com/nealmcc/aoc2022/pkg/vector/twod"
 
-// Linear defines a lower and upper bound (inclusive at both ends)
+// Linear defines a lower and upper bound (inclusive at both ends).
+// A Linear whose Min is greater than its Max is empty.
 type Linear struct {
 	Min int
 	Max int
 }
 
+// IsEmpty returns true iff this boundary contains no values.
+func (b Linear) IsEmpty() bool {
+	return b.Min > b.Max
+}
+
 // Size returns the size of this boundary
 func (b Linear) Size() int {
 	return b.Max - b.Min + 1
@@ -19,26 +25,124 @@ func (b Linear) Contains(n int) bool {
 	return b.Min <= n && n <= b.Max
 }
 
-// Mod returns the given value adjusted to fit within this boundary
+// Mod returns the given value adjusted to fit within this boundary.
+// It panics if the boundary is empty, since no value fits within it.
 func (b Linear) Mod(n int) int {
+	if b.IsEmpty() {
+		panic("bound: cannot wrap a value around an empty boundary")
+	}
 	return b.Min + mod(n-b.Min, b.Size())
 }
 
-// Rect defines a two-dimensional bounding box (inclusive at both ends)
+// Clamp returns the value within this boundary that is nearest to n.
+func (b Linear) Clamp(n int) int {
+	if n < b.Min {
+		return b.Min
+	}
+	if n > b.Max {
+		return b.Max
+	}
+	return n
+}
+
+// Rect defines a two-dimensional bounding box (inclusive at both ends).
+// A Rect whose Min is greater than its Max on either axis is empty.
 type Rect struct {
 	Min v.Point
 	Max v.Point
 }
 
+// _empty is the empty Rect that the other empty Rects are normalised to.
+var _empty = Rect{Max: v.Point{X: -1, Y: -1}}
+
+// FromPoints returns the smallest Rect that contains all of the given
+// points.  With no points, the Rect is empty.
+func FromPoints(points ...v.Point) Rect {
+	b := _empty
+	for _, p := range points {
+		b = b.Expand(p)
+	}
+	return b
+}
+
+// IsEmpty returns true iff this boundary contains no points.
+func (b Rect) IsEmpty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y
+}
+
 // Size returns the size of this boundary
 func (b Rect) Size() v.Point {
+	if b.IsEmpty() {
+		return v.Point{}
+	}
 	return b.Max.Sub(b.Min).Add(v.Point{X: 1, Y: 1})
 }
 
+// Area returns the number of points within this boundary.
+func (b Rect) Area() int {
+	size := b.Size()
+	return size.X * size.Y
+}
+
+// Expand returns the smallest Rect that contains both this boundary and
+// the given point.
+func (b Rect) Expand(p v.Point) Rect {
+	if b.IsEmpty() {
+		return Rect{Min: p, Max: p}
+	}
+	return Rect{Min: b.Min.Min(p), Max: b.Max.Max(p)}
+}
+
+// Union returns the smallest Rect that contains both a and b.
+func (a Rect) Union(b Rect) Rect {
+	switch {
+	case a.IsEmpty():
+		return b
+	case b.IsEmpty():
+		return a
+	}
+	return Rect{Min: a.Min.Min(b.Min), Max: a.Max.Max(b.Max)}
+}
+
+// Intersect returns the points that are within both a and b, and true iff
+// there are any.
+func (a Rect) Intersect(b Rect) (Rect, bool) {
+	res := Rect{Min: a.Min.Max(b.Min), Max: a.Max.Min(b.Max)}
+	if res.IsEmpty() {
+		return _empty, false
+	}
+	return res, true
+}
+
+// Corners returns the four corners of this boundary, clockwise from Min
+// (with Y increasing downwards): top left, top right, bottom right and
+// bottom left.
+func (b Rect) Corners() [4]v.Point {
+	return [4]v.Point{
+		b.Min,
+		{X: b.Max.X, Y: b.Min.Y},
+		b.Max,
+		{X: b.Min.X, Y: b.Max.Y},
+	}
+}
+
+// Each calls f for each point within this boundary, in row-major order
+// (along each row from Min.X to Max.X, and then down to the next row),
+// until f returns false.
+func (b Rect) Each(f func(p v.Point) bool) {
+	for y := b.Min.Y; y <= b.Max.Y; y++ {
+		for x := b.Min.X; x <= b.Max.X; x++ {
+			if !f(v.Point{X: x, Y: y}) {
+				return
+			}
+		}
+	}
+}
+
 // Contains checks to see if this boundary contains the given value.
 func (b Rect) Contains(p v.Point) bool {
 	return b.Min.X <= p.X && p.X <= b.Max.X &&
-		p.Y <= b.Min.Y && p.Y <= b.Max.Y
+		b.Min.Y <= p.Y && p.Y <= b.Max.Y
 }
 
 // Mod returns the given value adjusted to fit within this boundary
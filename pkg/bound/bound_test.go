@@ -0,0 +1,244 @@
+package bound
+
+import (
+	"testing"
+
+	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
+)
+
+func TestRect_Contains(t *testing.T) {
+	t.Parallel()
+
+	b := Rect{Min: v.Point{X: -1, Y: 2}, Max: v.Point{X: 3, Y: 5}}
+
+	tt := []struct {
+		name string
+		in   v.Point
+		want bool
+	}{
+		{name: "inside", in: v.Point{X: 0, Y: 3}, want: true},
+		{name: "min corner", in: b.Min, want: true},
+		{name: "max corner", in: b.Max, want: true},
+		{name: "above", in: v.Point{X: 0, Y: 1}, want: false},
+		{name: "below", in: v.Point{X: 0, Y: 6}, want: false},
+		{name: "left", in: v.Point{X: -2, Y: 3}, want: false},
+		{name: "right", in: v.Point{X: 4, Y: 3}, want: false},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := b.Contains(tc.in); got != tc.want {
+				t.Logf("%v.Contains(%v) = %t ; want %t", b, tc.in, got, tc.want)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestFromPoints(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		in        []v.Point
+		want      Rect
+		wantEmpty bool
+		wantArea  int
+	}{
+		{
+			name:      "no points",
+			wantEmpty: true,
+			wantArea:  0,
+		},
+		{
+			name:     "one point",
+			in:       []v.Point{{X: 4, Y: -2}},
+			want:     Rect{Min: v.Point{X: 4, Y: -2}, Max: v.Point{X: 4, Y: -2}},
+			wantArea: 1,
+		},
+		{
+			name:     "several points",
+			in:       []v.Point{{X: 1, Y: 1}, {X: -3, Y: 4}, {X: 2, Y: 0}},
+			want:     Rect{Min: v.Point{X: -3, Y: 0}, Max: v.Point{X: 2, Y: 4}},
+			wantArea: 30,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := FromPoints(tc.in...)
+			if got.IsEmpty() != tc.wantEmpty {
+				t.Fatalf("FromPoints(%v).IsEmpty() = %t ; want %t", tc.in, got.IsEmpty(), tc.wantEmpty)
+			}
+			if !tc.wantEmpty && got != tc.want {
+				t.Logf("FromPoints(%v) = %v ; want %v", tc.in, got, tc.want)
+				t.Fail()
+			}
+			if area := got.Area(); area != tc.wantArea {
+				t.Logf("FromPoints(%v).Area() = %d ; want %d", tc.in, area, tc.wantArea)
+				t.Fail()
+			}
+			for _, p := range tc.in {
+				if !got.Contains(p) {
+					t.Logf("%v does not contain %v", got, p)
+					t.Fail()
+				}
+			}
+		})
+	}
+}
+
+func TestRect_UnionIntersect(t *testing.T) {
+	t.Parallel()
+
+	r := func(x0, y0, x1, y1 int) Rect {
+		return Rect{Min: v.Point{X: x0, Y: y0}, Max: v.Point{X: x1, Y: y1}}
+	}
+
+	tt := []struct {
+		name      string
+		a, b      Rect
+		union     Rect
+		intersect Rect
+		overlap   bool
+	}{
+		{
+			name:      "overlapping",
+			a:         r(0, 0, 4, 4),
+			b:         r(2, -1, 6, 3),
+			union:     r(0, -1, 6, 4),
+			intersect: r(2, 0, 4, 3),
+			overlap:   true,
+		},
+		{
+			name:      "one inside the other",
+			a:         r(0, 0, 9, 9),
+			b:         r(3, 3, 4, 5),
+			union:     r(0, 0, 9, 9),
+			intersect: r(3, 3, 4, 5),
+			overlap:   true,
+		},
+		{
+			name:      "touching at a corner",
+			a:         r(0, 0, 2, 2),
+			b:         r(2, 2, 3, 3),
+			union:     r(0, 0, 3, 3),
+			intersect: r(2, 2, 2, 2),
+			overlap:   true,
+		},
+		{
+			name:    "apart",
+			a:       r(0, 0, 1, 1),
+			b:       r(5, 0, 6, 1),
+			union:   r(0, 0, 6, 1),
+			overlap: false,
+		},
+		{
+			name:    "with an empty rect",
+			a:       r(1, 2, 3, 4),
+			b:       FromPoints(),
+			union:   r(1, 2, 3, 4),
+			overlap: false,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, got := range []Rect{tc.a.Union(tc.b), tc.b.Union(tc.a)} {
+				if got != tc.union {
+					t.Logf("union of %v and %v = %v ; want %v", tc.a, tc.b, got, tc.union)
+					t.Fail()
+				}
+			}
+
+			got, ok := tc.a.Intersect(tc.b)
+			if ok != tc.overlap {
+				t.Fatalf("%v.Intersect(%v) ok = %t ; want %t", tc.a, tc.b, ok, tc.overlap)
+			}
+			if ok && got != tc.intersect {
+				t.Logf("%v.Intersect(%v) = %v ; want %v", tc.a, tc.b, got, tc.intersect)
+				t.Fail()
+			}
+			if !ok && !got.IsEmpty() {
+				t.Logf("%v.Intersect(%v) = %v ; want an empty rect", tc.a, tc.b, got)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestRect_Corners(t *testing.T) {
+	t.Parallel()
+
+	b := Rect{Min: v.Point{X: 1, Y: 2}, Max: v.Point{X: 5, Y: 7}}
+	want := [4]v.Point{{X: 1, Y: 2}, {X: 5, Y: 2}, {X: 5, Y: 7}, {X: 1, Y: 7}}
+	if got := b.Corners(); got != want {
+		t.Logf("%v.Corners() = %v ; want %v", b, got, want)
+		t.Fail()
+	}
+}
+
+func TestRect_Each(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		in    Rect
+		limit int // stop after this many points
+		want  []v.Point
+	}{
+		{
+			name:  "row-major order",
+			in:    Rect{Min: v.Point{X: -1, Y: 0}, Max: v.Point{X: 1, Y: 1}},
+			limit: 10,
+			want: []v.Point{
+				{X: -1, Y: 0}, {X: 0, Y: 0}, {X: 1, Y: 0},
+				{X: -1, Y: 1}, {X: 0, Y: 1}, {X: 1, Y: 1},
+			},
+		},
+		{
+			name:  "stop early",
+			in:    Rect{Max: v.Point{X: 9, Y: 9}},
+			limit: 2,
+			want:  []v.Point{{X: 0, Y: 0}, {X: 1, Y: 0}},
+		},
+		{
+			name:  "empty",
+			in:    FromPoints(),
+			limit: 10,
+			want:  nil,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []v.Point
+			tc.in.Each(func(p v.Point) bool {
+				got = append(got, p)
+				return len(got) < tc.limit
+			})
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("%v.Each() visited %v ; want %v", tc.in, got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Logf("%v.Each() visited %v ; want %v", tc.in, got, tc.want)
+					t.FailNow()
+				}
+			}
+		})
+	}
+}
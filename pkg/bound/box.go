@@ -0,0 +1,192 @@
+package bound
+
+import (
+	"fmt"
+
+	"github.com/nealmcc/aoc2022/pkg/vector/threed"
+	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
+)
+
+// Policy says what a Box does with a coordinate that is outside one of its
+// axes.
+type Policy int
+
+const (
+	// Reject coordinates outside the axis.  This is the zero value.
+	Reject Policy = iota
+	// Wrap coordinates around the axis, so that leaving one end of the axis
+	// comes back in at the other end.
+	Wrap
+	// Clamp coordinates to the nearest end of the axis.
+	Clamp
+)
+
+func (p Policy) String() string {
+	names := [...]string{"reject", "wrap", "clamp"}
+	if p < 0 || int(p) >= len(names) {
+		return fmt.Sprintf("Policy(%d)", p)
+	}
+	return names[p]
+}
+
+// Axis is one dimension of a Box: its lower and upper bound (inclusive at
+// both ends), and what to do with coordinates outside them.
+type Axis struct {
+	Linear
+	Policy Policy
+}
+
+// fit returns n adjusted to fit within this axis, using its policy.  It
+// returns false if n is outside the axis, and the axis rejects it, or if the
+// axis is empty, so that nothing fits.
+func (ax Axis) fit(n int) (int, bool) {
+	switch {
+	case ax.Contains(n):
+		return n, true
+	case ax.IsEmpty():
+		return 0, false
+	case ax.Policy == Wrap:
+		return ax.Mod(n), true
+	case ax.Policy == Clamp:
+		return ax.Clamp(n), true
+	default:
+		return 0, false
+	}
+}
+
+// Box is a bounding box in any number of dimensions, with one Axis for each
+// dimension.  Points are given as a slice of coordinates, one for each axis,
+// such as the result of twod.Point.Coords() or threed.Point.Coords().
+//
+// The methods that take a slice allocate a new slice for their result.  For
+// two and three dimensions, the methods with a 2 or 3 suffix take and return
+// points instead, without allocating, for use in a solver's inner loop.
+type Box []Axis
+
+// NewBox creates a box with the given lower and upper bounds, using the same
+// policy for every axis.  The bounds must have the same number of dimensions.
+func NewBox(min, max []int, policy Policy) Box {
+	if len(min) != len(max) {
+		panic("bound: NewBox needs the same number of dimensions for min and max")
+	}
+	b := make(Box, len(min))
+	for i := range b {
+		b[i] = Axis{Linear: Linear{Min: min[i], Max: max[i]}, Policy: policy}
+	}
+	return b
+}
+
+// Box returns a two-dimensional Box with the same bounds as this Rect, and
+// the given policy for both axes.
+func (b Rect) Box(policy Policy) Box {
+	return NewBox(b.Min.Coords(), b.Max.Coords(), policy)
+}
+
+// Size returns the size of this boundary along each axis.
+func (b Box) Size() []int {
+	res := make([]int, len(b))
+	for i, ax := range b {
+		res[i] = ax.Size()
+	}
+	return res
+}
+
+// Contains checks to see if this boundary contains the given point.
+func (b Box) Contains(p []int) bool {
+	b.check(p)
+	for i, ax := range b {
+		if !ax.Contains(p[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Mod returns the given point wrapped around every axis to fit within this
+// boundary, whatever the axes' policies.  It panics if any axis is empty.
+func (b Box) Mod(p []int) []int {
+	b.check(p)
+	res := make([]int, len(b))
+	for i, ax := range b {
+		res[i] = ax.Mod(p[i])
+	}
+	return res
+}
+
+// Clamp returns the given point moved to the nearest point within this
+// boundary, whatever the axes' policies.
+func (b Box) Clamp(p []int) []int {
+	b.check(p)
+	res := make([]int, len(b))
+	for i, ax := range b {
+		res[i] = ax.Clamp(p[i])
+	}
+	return res
+}
+
+// Fit returns the given point adjusted to fit within this boundary, using
+// the policy of each axis.  It returns false if the point is outside an axis
+// that rejects it, or if any axis is empty.
+func (b Box) Fit(p []int) ([]int, bool) {
+	b.check(p)
+	res := make([]int, len(b))
+	for i, ax := range b {
+		n, ok := ax.fit(p[i])
+		if !ok {
+			return nil, false
+		}
+		res[i] = n
+	}
+	return res, true
+}
+
+// Contains2 checks to see if this two-dimensional boundary contains the
+// given point.
+func (b Box) Contains2(p v.Point) bool {
+	b.checkLen(2)
+	return b[0].Contains(p.X) && b[1].Contains(p.Y)
+}
+
+// Fit2 returns the given point adjusted to fit within this two-dimensional
+// boundary, like Fit.
+func (b Box) Fit2(p v.Point) (v.Point, bool) {
+	b.checkLen(2)
+	x, okX := b[0].fit(p.X)
+	y, okY := b[1].fit(p.Y)
+	if !okX || !okY {
+		return v.Point{}, false
+	}
+	return v.Point{X: x, Y: y}, true
+}
+
+// Contains3 checks to see if this three-dimensional boundary contains the
+// given point.
+func (b Box) Contains3(p threed.Point) bool {
+	b.checkLen(3)
+	return b[0].Contains(p.X) && b[1].Contains(p.Y) && b[2].Contains(p.Z)
+}
+
+// Fit3 returns the given point adjusted to fit within this three-dimensional
+// boundary, like Fit.
+func (b Box) Fit3(p threed.Point) (threed.Point, bool) {
+	b.checkLen(3)
+	x, okX := b[0].fit(p.X)
+	y, okY := b[1].fit(p.Y)
+	z, okZ := b[2].fit(p.Z)
+	if !okX || !okY || !okZ {
+		return threed.Point{}, false
+	}
+	return threed.Point{X: x, Y: y, Z: z}, true
+}
+
+// check panics if the point does not have one coordinate for each axis.
+func (b Box) check(p []int) {
+	b.checkLen(len(p))
+}
+
+// checkLen panics if the box does not have n dimensions.
+func (b Box) checkLen(n int) {
+	if n != len(b) {
+		panic("bound: the point and the box have different numbers of dimensions")
+	}
+}
@@ -0,0 +1,184 @@
+package bound
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nealmcc/aoc2022/pkg/vector/threed"
+	v "github.com/nealmcc/aoc2022/pkg/vector/twod"
+)
+
+func TestBox_Fit(t *testing.T) {
+	t.Parallel()
+
+	// x wraps, y is clamped, and z is rejected:
+	box := Box{
+		{Linear: Linear{Min: 0, Max: 4}, Policy: Wrap},
+		{Linear: Linear{Min: -2, Max: 2}, Policy: Clamp},
+		{Linear: Linear{Min: 1, Max: 3}, Policy: Reject},
+	}
+
+	tt := []struct {
+		name   string
+		in     []int
+		want   []int
+		wantOK bool
+	}{
+		{name: "inside", in: []int{2, 0, 2}, want: []int{2, 0, 2}, wantOK: true},
+		{name: "on the corner", in: []int{4, -2, 3}, want: []int{4, -2, 3}, wantOK: true},
+		{name: "wrap past the end", in: []int{5, 0, 1}, want: []int{0, 0, 1}, wantOK: true},
+		{name: "wrap before the start", in: []int{-7, 0, 1}, want: []int{3, 0, 1}, wantOK: true},
+		{name: "clamp", in: []int{1, 9, 1}, want: []int{1, 2, 1}, wantOK: true},
+		{name: "clamp and wrap", in: []int{-1, -9, 1}, want: []int{4, -2, 1}, wantOK: true},
+		{name: "reject", in: []int{1, 0, 4}, wantOK: false},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := box.Fit(tc.in)
+			if ok != tc.wantOK {
+				t.Fatalf("Fit(%v) ok = %t ; want %t", tc.in, ok, tc.wantOK)
+			}
+			if ok && !reflect.DeepEqual(got, tc.want) {
+				t.Logf("Fit(%v) = %v ; want %v", tc.in, got, tc.want)
+				t.Fail()
+			}
+
+			inside := reflect.DeepEqual(tc.in, tc.want)
+			if box.Contains(tc.in) != inside {
+				t.Logf("Contains(%v) = %t ; want %t", tc.in, !inside, inside)
+				t.Fail()
+			}
+
+			// the three-dimensional methods agree with the others:
+			p := threed.Point{X: tc.in[0], Y: tc.in[1], Z: tc.in[2]}
+			got3, ok3 := box.Fit3(p)
+			if ok3 != ok || ok && !reflect.DeepEqual(got3.Coords(), got) {
+				t.Logf("Fit3(%v) = %v, %t ; want %v, %t", p, got3, ok3, got, ok)
+				t.Fail()
+			}
+			if box.Contains3(p) != inside {
+				t.Logf("Contains3(%v) = %t ; want %t", p, !inside, inside)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestBox_ModClamp(t *testing.T) {
+	t.Parallel()
+
+	box := NewBox([]int{0, 10}, []int{3, 12}, Reject)
+
+	tt := []struct {
+		name string
+		got  []int
+		want []int
+	}{
+		{name: "size", got: box.Size(), want: []int{4, 3}},
+		{name: "mod ignores the policy", got: box.Mod([]int{-1, 13}), want: []int{3, 10}},
+		{name: "clamp ignores the policy", got: box.Clamp([]int{-1, 13}), want: []int{0, 12}},
+		{name: "mod inside", got: box.Mod([]int{2, 11}), want: []int{2, 11}},
+	}
+
+	for _, tc := range tt {
+		if !reflect.DeepEqual(tc.got, tc.want) {
+			t.Logf("%s: got %v ; want %v", tc.name, tc.got, tc.want)
+			t.Fail()
+		}
+	}
+}
+
+func TestBox_empty(t *testing.T) {
+	t.Parallel()
+
+	// the y axis is empty, so nothing fits within the box:
+	for _, policy := range []Policy{Reject, Wrap, Clamp} {
+		box := NewBox([]int{0, 3}, []int{4, 2}, policy)
+		if got, ok := box.Fit([]int{1, 2}); ok {
+			t.Logf("%s: Fit() = %v, true ; want false", policy, got)
+			t.Fail()
+		}
+		if got, ok := box.Fit2(v.Point{X: 1, Y: 2}); ok {
+			t.Logf("%s: Fit2() = %v, true ; want false", policy, got)
+			t.Fail()
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Log("wrapping around an empty axis should panic")
+			t.Fail()
+		}
+	}()
+	Linear{Min: 3, Max: 2}.Mod(1)
+}
+
+func TestPolicy_String(t *testing.T) {
+	t.Parallel()
+
+	for p, want := range map[Policy]string{
+		Reject:     "reject",
+		Wrap:       "wrap",
+		Clamp:      "clamp",
+		Policy(7):  "Policy(7)",
+		Policy(-1): "Policy(-1)",
+	} {
+		if got := p.String(); got != want {
+			t.Logf("Policy(%d).String() = %q ; want %q", int(p), got, want)
+			t.Fail()
+		}
+	}
+}
+
+func TestRect_Box(t *testing.T) {
+	t.Parallel()
+
+	r := Rect{Min: v.Point{X: 0, Y: 0}, Max: v.Point{X: 5, Y: 3}}
+	box := r.Box(Wrap)
+
+	for _, p := range []v.Point{{X: 6, Y: -1}, {X: -13, Y: 9}, {X: 2, Y: 2}} {
+		want := r.Mod(p)
+		got, ok := box.Fit(p.Coords())
+		if !ok || !reflect.DeepEqual(got, want.Coords()) {
+			t.Logf("Fit(%v) = %v, %t ; want %v, true", p, got, ok, want)
+			t.Fail()
+		}
+		if got2, ok := box.Fit2(p); !ok || got2 != want {
+			t.Logf("Fit2(%v) = %v, %t ; want %v, true", p, got2, ok, want)
+			t.Fail()
+		}
+		if box.Contains2(p) != r.Contains(p) {
+			t.Logf("Contains2(%v) = %t ; want %t", p, !r.Contains(p), r.Contains(p))
+			t.Fail()
+		}
+	}
+
+	if _, ok := r.Box(Reject).Fit2(v.Point{X: 6, Y: 1}); ok {
+		t.Log("Fit2 should reject a point outside a box that rejects it")
+		t.Fail()
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Log("a point with the wrong number of dimensions should panic")
+			t.Fail()
+		}
+	}()
+	box.Contains([]int{1, 2, 3})
+}
+
+var _result v.Point // prevent the compiler from optimising away the call.
+
+func BenchmarkBox_Fit2(b *testing.B) {
+	box := Rect{Min: v.Point{X: 0, Y: 0}, Max: v.Point{X: 119, Y: 24}}.Box(Wrap)
+	p := v.Point{X: -1000, Y: 1000}
+
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		_result, _ = box.Fit2(p)
+	}
+}
@@ -0,0 +1,180 @@
+package bound
+
+import "sort"
+
+// IntervalSet is a set of integers, stored as a sorted list of disjoint
+// intervals.  Intervals that overlap or touch are merged together, so each
+// interval is separated from the next by a gap of at least one.
+// The zero value is the empty set, ready to use.
+type IntervalSet struct {
+	spans []Linear
+}
+
+// NewIntervalSet creates a set that contains all of the given intervals.
+func NewIntervalSet(spans ...Linear) *IntervalSet {
+	s := new(IntervalSet)
+	for _, span := range spans {
+		s.Add(span)
+	}
+	return s
+}
+
+// Spans returns a copy of the intervals in the set, in ascending order.
+func (s *IntervalSet) Spans() []Linear {
+	return append([]Linear(nil), s.spans...)
+}
+
+// Len returns the number of integers in the set.
+func (s *IntervalSet) Len() int {
+	n := 0
+	for _, span := range s.spans {
+		n += span.Size()
+	}
+	return n
+}
+
+// Contains checks to see if the given value is in the set.
+func (s *IntervalSet) Contains(n int) bool {
+	i := sort.Search(len(s.spans), func(i int) bool {
+		return s.spans[i].Max >= n
+	})
+	return i < len(s.spans) && s.spans[i].Contains(n)
+}
+
+// Add all of the values in the given interval to the set.
+func (s *IntervalSet) Add(span Linear) {
+	if span.IsEmpty() {
+		return
+	}
+
+	// the intervals from i up to j overlap or touch the new one:
+	i := sort.Search(len(s.spans), func(i int) bool {
+		return s.spans[i].Max >= span.Min-1
+	})
+	j := sort.Search(len(s.spans), func(j int) bool {
+		return s.spans[j].Min > span.Max+1
+	})
+
+	if i < j {
+		if s.spans[i].Min < span.Min {
+			span.Min = s.spans[i].Min
+		}
+		if s.spans[j-1].Max > span.Max {
+			span.Max = s.spans[j-1].Max
+		}
+	}
+
+	if i == j {
+		// nothing to merge with, so make room for the new interval:
+		s.spans = append(s.spans, Linear{})
+		copy(s.spans[i+1:], s.spans[i:])
+		s.spans[i] = span
+		return
+	}
+
+	// replace the intervals from i up to j with the merged one:
+	s.spans[i] = span
+	s.spans = append(s.spans[:i+1], s.spans[j:]...)
+}
+
+// Clear removes all of the values from the set, keeping its storage so that
+// it can be filled again without allocating.
+func (s *IntervalSet) Clear() {
+	s.spans = s.spans[:0]
+}
+
+// Remove all of the values in the given interval from the set.
+func (s *IntervalSet) Remove(span Linear) {
+	if span.IsEmpty() {
+		return
+	}
+
+	spans := make([]Linear, 0, len(s.spans)+1)
+	for _, curr := range s.spans {
+		if curr.Max < span.Min || curr.Min > span.Max {
+			spans = append(spans, curr)
+			continue
+		}
+		if curr.Min < span.Min {
+			spans = append(spans, Linear{Min: curr.Min, Max: span.Min - 1})
+		}
+		if curr.Max > span.Max {
+			spans = append(spans, Linear{Min: span.Max + 1, Max: curr.Max})
+		}
+	}
+	s.spans = spans
+}
+
+// Intersect returns a new set with the values that are in both s and other.
+func (s *IntervalSet) Intersect(other *IntervalSet) *IntervalSet {
+	res := new(IntervalSet)
+	for i, j := 0, 0; i < len(s.spans) && j < len(other.spans); {
+		a, b := s.spans[i], other.spans[j]
+		overlap := Linear{Min: max(a.Min, b.Min), Max: min(a.Max, b.Max)}
+		if !overlap.IsEmpty() {
+			res.spans = append(res.spans, overlap)
+		}
+		// move past whichever interval ends first:
+		if a.Max < b.Max {
+			i++
+		} else {
+			j++
+		}
+	}
+	return res
+}
+
+// Complement returns a new set with the values within the given interval
+// that are not in s.
+func (s *IntervalSet) Complement(within Linear) *IntervalSet {
+	res := new(IntervalSet)
+	if within.IsEmpty() {
+		return res
+	}
+
+	next := within.Min // the smallest value that might be in the result
+	for _, span := range s.spans {
+		if span.Max < next {
+			continue
+		}
+		if span.Min > within.Max {
+			break
+		}
+		if span.Min > next {
+			res.spans = append(res.spans, Linear{Min: next, Max: span.Min - 1})
+		}
+		next = span.Max + 1
+	}
+	if next <= within.Max {
+		res.spans = append(res.spans, Linear{Min: next, Max: within.Max})
+	}
+	return res
+}
+
+// Gaps returns the intervals between the intervals of the set, in ascending
+// order: the values that are not in the set, but are between its smallest
+// and largest values.
+func (s *IntervalSet) Gaps() []Linear {
+	if len(s.spans) < 2 {
+		return nil
+	}
+	gaps := make([]Linear, 0, len(s.spans)-1)
+	for i := 1; i < len(s.spans); i++ {
+		gaps = append(gaps, Linear{Min: s.spans[i-1].Max + 1, Max: s.spans[i].Min - 1})
+	}
+	return gaps
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,234 @@
+package bound
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalSet_Add(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		in   []Linear
+		want []Linear
+	}{
+		{
+			name: "an empty list produces an empty set",
+			in:   nil,
+			want: nil,
+		},
+		{
+			name: "a single interval",
+			in:   []Linear{{Min: -2, Max: 2}},
+			want: []Linear{{Min: -2, Max: 2}},
+		},
+		{
+			name: "empty intervals are ignored",
+			in:   []Linear{{Min: 3, Max: 2}},
+			want: nil,
+		},
+		{
+			name: "two overlapping intervals are merged into one",
+			in:   []Linear{{Min: -2, Max: 2}, {Min: 0, Max: 2}},
+			want: []Linear{{Min: -2, Max: 2}},
+		},
+		{
+			name: "two adjacent intervals are merged into one",
+			in:   []Linear{{Min: -2, Max: 2}, {Min: 3, Max: 5}},
+			want: []Linear{{Min: -2, Max: 5}},
+		},
+		{
+			name: "two distinct intervals remain distinct",
+			in:   []Linear{{Min: -2, Max: 1}, {Min: 3, Max: 5}},
+			want: []Linear{{Min: -2, Max: 1}, {Min: 3, Max: 5}},
+		},
+		{
+			name: "some intervals are joined and others are not",
+			in:   []Linear{{Min: -2, Max: 2}, {Min: -1, Max: 3}, {Min: 5, Max: 8}},
+			want: []Linear{{Min: -2, Max: 3}, {Min: 5, Max: 8}},
+		},
+		{
+			name: "handles intervals in arbitrary order",
+			in: []Linear{
+				{Min: -1, Max: 3},
+				{Min: 5, Max: 8},
+				{Min: -2, Max: 2},
+				{Min: -12, Max: -4},
+			},
+			want: []Linear{{Min: -12, Max: -4}, {Min: -2, Max: 3}, {Min: 5, Max: 8}},
+		},
+		{
+			name: "one interval can join several others",
+			in:   []Linear{{Min: 0, Max: 1}, {Min: 4, Max: 5}, {Min: 8, Max: 9}, {Min: 2, Max: 7}},
+			want: []Linear{{Min: 0, Max: 9}},
+		},
+		{
+			name: "an interval can be inserted between others",
+			in:   []Linear{{Min: 0, Max: 1}, {Min: 8, Max: 9}, {Min: 4, Max: 5}, {Min: -4, Max: -3}},
+			want: []Linear{{Min: -4, Max: -3}, {Min: 0, Max: 1}, {Min: 4, Max: 5}, {Min: 8, Max: 9}},
+		},
+		{
+			name: "joining some intervals keeps the ones after them",
+			in:   []Linear{{Min: 0, Max: 1}, {Min: 4, Max: 5}, {Min: 8, Max: 9}, {Min: 12, Max: 13}, {Min: 1, Max: 4}},
+			want: []Linear{{Min: 0, Max: 5}, {Min: 8, Max: 9}, {Min: 12, Max: 13}},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := NewIntervalSet(tc.in...)
+			assert.Equal(t, tc.want, s.Spans())
+		})
+	}
+}
+
+func TestIntervalSet_Clear(t *testing.T) {
+	t.Parallel()
+
+	s := NewIntervalSet(Linear{Min: 0, Max: 3}, Linear{Min: 6, Max: 9})
+	spans := s.Spans()
+
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+	assert.False(t, s.Contains(0))
+
+	s.Add(Linear{Min: 4, Max: 5})
+	assert.Equal(t, []Linear{{Min: 4, Max: 5}}, s.Spans())
+	assert.Equal(t, []Linear{{Min: 0, Max: 3}, {Min: 6, Max: 9}}, spans,
+		"the spans returned before clearing should not change")
+}
+
+func BenchmarkIntervalSet_Add(b *testing.B) {
+	in := make([]Linear, 0, 32)
+	for i := 0; i < cap(in); i++ {
+		x := (i * 7919) % 1000
+		in = append(in, Linear{Min: x * 10, Max: x*10 + 12})
+	}
+	s := new(IntervalSet)
+
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		s.Clear()
+		for _, span := range in {
+			s.Add(span)
+		}
+	}
+}
+
+func TestIntervalSet_Remove(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		remove Linear
+		want   []Linear
+	}{
+		{
+			name:   "from the middle of an interval",
+			remove: Linear{Min: 2, Max: 3},
+			want:   []Linear{{Min: 0, Max: 1}, {Min: 4, Max: 5}, {Min: 10, Max: 15}},
+		},
+		{
+			name:   "across a gap",
+			remove: Linear{Min: 4, Max: 11},
+			want:   []Linear{{Min: 0, Max: 3}, {Min: 12, Max: 15}},
+		},
+		{
+			name:   "a whole interval",
+			remove: Linear{Min: -1, Max: 6},
+			want:   []Linear{{Min: 10, Max: 15}},
+		},
+		{
+			name:   "nothing in the set",
+			remove: Linear{Min: 6, Max: 9},
+			want:   []Linear{{Min: 0, Max: 5}, {Min: 10, Max: 15}},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			s := NewIntervalSet(Linear{Min: 0, Max: 5}, Linear{Min: 10, Max: 15})
+			s.Remove(tc.remove)
+			assert.Equal(t, tc.want, s.Spans())
+		})
+	}
+}
+
+func TestIntervalSet_Contains(t *testing.T) {
+	t.Parallel()
+
+	s := NewIntervalSet(Linear{Min: -3, Max: 0}, Linear{Min: 4, Max: 4})
+
+	for n, want := range map[int]bool{-4: false, -3: true, 0: true, 1: false, 4: true, 5: false} {
+		assert.Equal(t, want, s.Contains(n), "Contains(%d)", n)
+	}
+	assert.Equal(t, 5, s.Len())
+	assert.False(t, new(IntervalSet).Contains(0))
+}
+
+func TestIntervalSet_Intersect(t *testing.T) {
+	t.Parallel()
+
+	a := NewIntervalSet(Linear{Min: 0, Max: 5}, Linear{Min: 10, Max: 15})
+	b := NewIntervalSet(Linear{Min: 3, Max: 11}, Linear{Min: 14, Max: 20})
+
+	want := []Linear{{Min: 3, Max: 5}, {Min: 10, Max: 11}, {Min: 14, Max: 15}}
+	assert.Equal(t, want, a.Intersect(b).Spans())
+	assert.Equal(t, want, b.Intersect(a).Spans())
+	assert.Empty(t, a.Intersect(new(IntervalSet)).Spans())
+}
+
+func TestIntervalSet_Complement(t *testing.T) {
+	t.Parallel()
+
+	s := NewIntervalSet(Linear{Min: -1, Max: 1}, Linear{Min: 3, Max: 4}, Linear{Min: 9, Max: 12})
+
+	tt := []struct {
+		name   string
+		within Linear
+		want   []Linear
+	}{
+		{
+			name:   "a domain of length 1 that is covered",
+			within: Linear{Min: 0, Max: 0},
+			want:   nil,
+		},
+		{
+			name:   "intervals overlap each end",
+			within: Linear{Min: 0, Max: 10},
+			want:   []Linear{{Min: 2, Max: 2}, {Min: 5, Max: 8}},
+		},
+		{
+			name:   "a domain outside the set",
+			within: Linear{Min: -10, Max: -8},
+			want:   []Linear{{Min: -10, Max: -8}},
+		},
+		{
+			name:   "a domain beyond both ends",
+			within: Linear{Min: -3, Max: 14},
+			want: []Linear{
+				{Min: -3, Max: -2},
+				{Min: 2, Max: 2},
+				{Min: 5, Max: 8},
+				{Min: 13, Max: 14},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, s.Complement(tc.within).Spans())
+		})
+	}
+
+	assert.Equal(t, []Linear{{Min: 2, Max: 2}, {Min: 5, Max: 8}}, s.Gaps())
+	assert.Nil(t, NewIntervalSet(Linear{Min: 0, Max: 9}).Gaps())
+}
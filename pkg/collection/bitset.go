@@ -0,0 +1,314 @@
+package collection
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// BitSet64 is a set of small integers, from 0 to 63, packed into a single
+// word.  It is comparable, so it can be used as (part of) a map key, and
+// copying it copies the set.
+// The zero value is the empty set.
+type BitSet64 uint64
+
+// NewBitSet64 creates a set with the given values.
+// It panics if any of the values is outside [0, 64).
+func NewBitSet64(vals ...int) BitSet64 {
+	var b BitSet64
+	return b.With(vals...)
+}
+
+// BitSet64FromSet converts the given set to a BitSet64.
+// It panics if any of the values is outside [0, 64).
+func BitSet64FromSet(s Set[int]) BitSet64 {
+	var b BitSet64
+	for k := range s {
+		b.Add(k)
+	}
+	return b
+}
+
+// Add the given value to the set.
+func (b *BitSet64) Add(i int) {
+	*b |= bit64(i)
+}
+
+// Remove the given value from the set.
+func (b *BitSet64) Remove(i int) {
+	*b &^= bit64(i)
+}
+
+// With returns a copy of the set with the given values added.
+func (b BitSet64) With(vals ...int) BitSet64 {
+	for _, i := range vals {
+		b |= bit64(i)
+	}
+	return b
+}
+
+// Contains returns true iff this set contains the given value.
+// Values outside [0, 64) are never in the set.
+func (b BitSet64) Contains(i int) bool {
+	return i >= 0 && i < 64 && b&(1<<i) != 0
+}
+
+// Len returns the number of values in the set.
+func (b BitSet64) Len() int {
+	return bits.OnesCount64(uint64(b))
+}
+
+// Union returns the values that are in b or in any of the others.
+func (b BitSet64) Union(others ...BitSet64) BitSet64 {
+	for _, o := range others {
+		b |= o
+	}
+	return b
+}
+
+// Intersect returns the values that are in b and in every one of the others.
+func (b BitSet64) Intersect(others ...BitSet64) BitSet64 {
+	for _, o := range others {
+		b &= o
+	}
+	return b
+}
+
+// Difference returns the values that are in b but not in other.
+func (b BitSet64) Difference(other BitSet64) BitSet64 {
+	return b &^ other
+}
+
+// SymmetricDifference returns the values that are in either b or other, but
+// not in both.
+func (b BitSet64) SymmetricDifference(other BitSet64) BitSet64 {
+	return b ^ other
+}
+
+// IsSubset returns true iff every value in b is also in other.
+func (b BitSet64) IsSubset(other BitSet64) bool {
+	return b&^other == 0
+}
+
+// Min returns the smallest value in the set, and true iff the set is not
+// empty.
+func (b BitSet64) Min() (int, bool) {
+	if b == 0 {
+		return 0, false
+	}
+	return bits.TrailingZeros64(uint64(b)), true
+}
+
+// Each calls f for each value in the set, in ascending order, until f
+// returns false.
+func (b BitSet64) Each(f func(i int) bool) {
+	for w := uint64(b); w != 0; w &= w - 1 {
+		if !f(bits.TrailingZeros64(w)) {
+			return
+		}
+	}
+}
+
+// Set converts b to a Set.
+func (b BitSet64) Set() Set[int] {
+	s := make(Set[int], b.Len())
+	b.Each(func(i int) bool {
+		s.Add(i)
+		return true
+	})
+	return s
+}
+
+// String implements fmt.Stringer.
+// It writes the values in ascending order.
+func (b BitSet64) String() string {
+	return formatBits(b.Each)
+}
+
+// bit64 returns a word with only the i'th bit set.
+func bit64(i int) BitSet64 {
+	if i < 0 || i >= 64 {
+		panic(fmt.Sprintf("collection: BitSet64 value %d out of range", i))
+	}
+	return 1 << i
+}
+
+// BitSet is a set of non-negative integers, stored as a bitmask that grows
+// as needed.  It suits dense sets of small integers, such as indexes into a
+// slice.  It is not safe to use concurrently.
+// The zero value is the empty set, ready to use.
+type BitSet struct {
+	words []uint64
+}
+
+// NewBitSet creates a set with the given values.
+// It panics if any of the values is negative.
+func NewBitSet(vals ...int) *BitSet {
+	b := new(BitSet)
+	for _, i := range vals {
+		b.Add(i)
+	}
+	return b
+}
+
+// BitSetFromSet converts the given set to a BitSet.
+// It panics if any of the values is negative.
+func BitSetFromSet(s Set[int]) *BitSet {
+	b := new(BitSet)
+	for k := range s {
+		b.Add(k)
+	}
+	return b
+}
+
+// Add the given value to the set.
+// It panics if the value is negative.
+func (b *BitSet) Add(i int) {
+	if i < 0 {
+		panic(fmt.Sprintf("collection: BitSet value %d out of range", i))
+	}
+	w := i / 64
+	if w >= len(b.words) {
+		words := make([]uint64, w+1, 2*(w+1))
+		copy(words, b.words)
+		b.words = words
+	}
+	b.words[w] |= 1 << (i % 64)
+}
+
+// Remove the given value from the set.
+func (b *BitSet) Remove(i int) {
+	if i >= 0 && i/64 < len(b.words) {
+		b.words[i/64] &^= 1 << (i % 64)
+	}
+}
+
+// Contains returns true iff this set contains the given value.
+func (b *BitSet) Contains(i int) bool {
+	return i >= 0 && i/64 < len(b.words) && b.words[i/64]&(1<<(i%64)) != 0
+}
+
+// Len returns the number of values in the set.
+func (b *BitSet) Len() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Clone returns a copy of the set.
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+// UnionWith adds the values of each of the other sets to this set.
+func (b *BitSet) UnionWith(others ...*BitSet) {
+	for _, o := range others {
+		if len(o.words) > len(b.words) {
+			words := make([]uint64, len(o.words))
+			copy(words, b.words)
+			b.words = words
+		}
+		for i, w := range o.words {
+			b.words[i] |= w
+		}
+	}
+}
+
+// IntersectWith removes the values of this set that are not in every one of
+// the other sets.
+func (b *BitSet) IntersectWith(others ...*BitSet) {
+	for _, o := range others {
+		for i := range b.words {
+			if i < len(o.words) {
+				b.words[i] &= o.words[i]
+			} else {
+				b.words[i] = 0
+			}
+		}
+	}
+}
+
+// DifferenceWith removes the values of the other set from this set.
+func (b *BitSet) DifferenceWith(other *BitSet) {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &^= other.words[i]
+		}
+	}
+}
+
+// IsSubset returns true iff every value in b is also in other.
+func (b *BitSet) IsSubset(other *BitSet) bool {
+	for i, w := range b.words {
+		var o uint64
+		if i < len(other.words) {
+			o = other.words[i]
+		}
+		if w&^o != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns true iff b and other have the same values.
+func (b *BitSet) Equal(other *BitSet) bool {
+	return b.IsSubset(other) && other.IsSubset(b)
+}
+
+// Min returns the smallest value in the set, and true iff the set is not
+// empty.
+func (b *BitSet) Min() (int, bool) {
+	for i, w := range b.words {
+		if w != 0 {
+			return i*64 + bits.TrailingZeros64(w), true
+		}
+	}
+	return 0, false
+}
+
+// Each calls f for each value in the set, in ascending order, until f
+// returns false.  The set must not be changed while Each is running.
+func (b *BitSet) Each(f func(i int) bool) {
+	for i, w := range b.words {
+		for ; w != 0; w &= w - 1 {
+			if !f(i*64 + bits.TrailingZeros64(w)) {
+				return
+			}
+		}
+	}
+}
+
+// Set converts b to a Set.
+func (b *BitSet) Set() Set[int] {
+	s := make(Set[int], b.Len())
+	b.Each(func(i int) bool {
+		s.Add(i)
+		return true
+	})
+	return s
+}
+
+// String implements fmt.Stringer.
+// It writes the values in ascending order.
+func (b *BitSet) String() string {
+	return formatBits(b.Each)
+}
+
+// formatBits writes the values visited by each with the same brackets and
+// separators as a Set, but in numeric order rather than a Set's string order.
+func formatBits(each func(f func(i int) bool)) string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	each(func(i int) bool {
+		if sb.Len() > 1 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, i)
+		return true
+	})
+	sb.WriteByte(']')
+	return sb.String()
+}
@@ -0,0 +1,167 @@
+package collection
+
+import (
+	"reflect"
+	"testing"
+)
+
+// bitsOf collects the values visited by each, in order.
+func bitsOf(each func(f func(i int) bool)) []int {
+	res := []int{}
+	each(func(i int) bool {
+		res = append(res, i)
+		return true
+	})
+	return res
+}
+
+func TestBitSet64_algebra(t *testing.T) {
+	t.Parallel()
+
+	a := NewBitSet64(1, 2, 3, 4)
+	b := NewBitSet64(3, 4, 5)
+	c := NewBitSet64(4, 5, 63)
+
+	tt := []struct {
+		name string
+		got  BitSet64
+		want []int
+	}{
+		{name: "union", got: a.Union(b, c), want: []int{1, 2, 3, 4, 5, 63}},
+		{name: "intersect", got: a.Intersect(b, c), want: []int{4}},
+		{name: "intersect two", got: a.Intersect(b), want: []int{3, 4}},
+		{name: "difference", got: a.Difference(b), want: []int{1, 2}},
+		{name: "symmetric difference", got: a.SymmetricDifference(b), want: []int{1, 2, 5}},
+		{name: "with", got: a.With(0, 9), want: []int{0, 1, 2, 3, 4, 9}},
+		{name: "empty", got: BitSet64(0), want: []int{}},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := bitsOf(tc.got.Each)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Logf("got %v ; want %v", got, tc.want)
+				t.Fail()
+			}
+			if tc.got.Len() != len(tc.want) {
+				t.Logf("Len() = %d ; want %d", tc.got.Len(), len(tc.want))
+				t.Fail()
+			}
+		})
+	}
+
+	if a.Len() != 4 {
+		t.Log("the operations should not modify their operands")
+		t.Fail()
+	}
+}
+
+func TestBitSet64_AddRemove(t *testing.T) {
+	t.Parallel()
+
+	var b BitSet64
+	b.Add(0)
+	b.Add(63)
+	b.Add(7)
+	b.Remove(7)
+	b.Remove(8)
+
+	for _, i := range []int{-1, 7, 8, 64} {
+		if b.Contains(i) {
+			t.Logf("b.Contains(%d) = true ; want false", i)
+			t.Fail()
+		}
+	}
+	if got, want := b.String(), "[0, 63]"; got != want {
+		t.Logf("b.String() = %q ; want %q", got, want)
+		t.Fail()
+	}
+	if min, ok := b.Min(); min != 0 || !ok {
+		t.Logf("b.Min() = %d, %t ; want 0, true", min, ok)
+		t.Fail()
+	}
+	if _, ok := BitSet64(0).Min(); ok {
+		t.Log("Min() of the empty set should return false")
+		t.Fail()
+	}
+	if !b.IsSubset(NewBitSet64(0, 1, 63)) || b.IsSubset(NewBitSet64(0)) {
+		t.Logf("IsSubset gave the wrong answer for %v", b)
+		t.Fail()
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Log("adding 64 should panic")
+			t.Fail()
+		}
+	}()
+	b.Add(64)
+}
+
+func TestBitSet64_Set(t *testing.T) {
+	t.Parallel()
+
+	s := NewSet(0, 5, 42)
+	b := BitSet64FromSet(s)
+	if b != NewBitSet64(42, 5, 0) {
+		t.Logf("BitSet64FromSet(%v) = %v", s, b)
+		t.Fail()
+	}
+	if !b.Set().Equal(s) {
+		t.Logf("b.Set() = %v ; want %v", b.Set(), s)
+		t.Fail()
+	}
+}
+
+func TestBitSet(t *testing.T) {
+	t.Parallel()
+
+	var b BitSet
+	for _, i := range []int{1, 64, 200, 3} {
+		b.Add(i)
+	}
+	b.Remove(3)
+	b.Remove(1000)
+
+	if got, want := bitsOf(b.Each), []int{1, 64, 200}; !reflect.DeepEqual(got, want) {
+		t.Logf("values = %v ; want %v", got, want)
+		t.Fail()
+	}
+	if b.Len() != 3 || !b.Contains(200) || b.Contains(199) || b.Contains(-1) {
+		t.Logf("Len or Contains gave the wrong answer for %v", &b)
+		t.Fail()
+	}
+	if min, ok := b.Min(); min != 1 || !ok {
+		t.Logf("b.Min() = %d, %t ; want 1, true", min, ok)
+		t.Fail()
+	}
+
+	clone := b.Clone()
+	clone.UnionWith(NewBitSet(2, 500))
+	clone.IntersectWith(NewBitSet(1, 2, 64, 500, 600))
+	if want := NewBitSet(1, 2, 64, 500); !clone.Equal(want) {
+		t.Logf("clone = %v ; want %v", clone, want)
+		t.Fail()
+	}
+	clone.DifferenceWith(NewBitSet(2, 500))
+	if want := NewBitSet(1, 64); !clone.Equal(want) {
+		t.Logf("clone = %v ; want %v", clone, want)
+		t.Fail()
+	}
+	if !clone.IsSubset(&b) || b.IsSubset(clone) {
+		t.Logf("IsSubset gave the wrong answer for %v and %v", clone, &b)
+		t.Fail()
+	}
+	if b.Len() != 3 {
+		t.Log("changing a clone should not change the original")
+		t.Fail()
+	}
+
+	if !BitSetFromSet(b.Set()).Equal(&b) {
+		t.Logf("converting %v to a Set and back gave %v", &b, BitSetFromSet(b.Set()))
+		t.Fail()
+	}
+}
@@ -0,0 +1,192 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when pushing to a closed ConcurrentQueue, or when
+// popping from one that is closed and empty.
+var ErrClosed = errors.New("queue is closed")
+
+// ConcurrentQueue is a generic first-in, first-out container that is safe to
+// use concurrently, for passing items from producers to consumers.
+//
+// Pop blocks until there is an item to pop.  If the queue has a capacity,
+// then Push blocks until there is room for the item.  Once the queue is
+// closed, no more items can be pushed, but the items already in the queue
+// can still be popped.
+//
+// Use NewConcurrentQueue to create a ConcurrentQueue.
+type ConcurrentQueue[T any] struct {
+	mu       sync.Mutex
+	items    Queue[T]
+	capacity int           // zero for unbounded
+	closed   bool          // no more items can be pushed
+	notEmpty chan struct{} // wakes one waiting Pop when there may be an item
+	notFull  chan struct{} // wakes one waiting Push when there may be room
+	done     chan struct{} // closed when the queue is closed, to wake everyone
+}
+
+// NewConcurrentQueue creates an empty queue that holds at most capacity
+// items.  A capacity of zero or less means that the queue is unbounded.
+func NewConcurrentQueue[T any](capacity int) *ConcurrentQueue[T] {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &ConcurrentQueue[T]{
+		capacity: capacity,
+		notEmpty: make(chan struct{}, 1),
+		notFull:  make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+}
+
+// Len returns the number of items in this queue.
+func (q *ConcurrentQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
+
+// Push the given item to the back of the queue, waiting for room if the
+// queue is full.  It returns ErrClosed if the queue is closed, or the
+// context's error if the context is done before there is room.
+func (q *ConcurrentQueue[T]) Push(ctx context.Context, x T) error {
+	for {
+		q.mu.Lock()
+		switch {
+		case q.closed:
+			q.mu.Unlock()
+			return ErrClosed
+
+		case q.hasRoom():
+			q.items.Push(x)
+			q.pushed()
+			q.mu.Unlock()
+			return nil
+		}
+
+		if err := q.wait(ctx, q.notFull); err != nil {
+			return err
+		}
+	}
+}
+
+// TryPush pushes the given item to the back of the queue, and returns true,
+// iff the queue is open and has room for it.
+func (q *ConcurrentQueue[T]) TryPush(x T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || !q.hasRoom() {
+		return false
+	}
+	q.items.Push(x)
+	q.pushed()
+	return true
+}
+
+// Pop returns the front item from the queue, waiting for one if the queue is
+// empty.  It returns ErrClosed if the queue is closed and empty, or the
+// context's error if the context is done before there is an item.
+func (q *ConcurrentQueue[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		if x, ok := q.items.Pop(); ok {
+			q.popped()
+			q.mu.Unlock()
+			return x, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			var zero T
+			return zero, ErrClosed
+		}
+
+		if err := q.wait(ctx, q.notEmpty); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+// TryPop returns the front item from the queue, and true iff there is an
+// item.  It does not wait.
+func (q *ConcurrentQueue[T]) TryPop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	x, ok := q.items.Pop()
+	if ok {
+		q.popped()
+	}
+	return x, ok
+}
+
+// Close stops any more items from being pushed, and wakes everyone who is
+// waiting.  Items already in the queue can still be popped.  Closing a
+// closed queue has no effect.
+func (q *ConcurrentQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		close(q.done)
+	}
+}
+
+// hasRoom returns true iff there is room for another item.
+// The lock must be held.
+func (q *ConcurrentQueue[T]) hasRoom() bool {
+	return q.capacity == 0 || q.items.Len() < q.capacity
+}
+
+// pushed wakes one waiting Pop, after an item has been pushed.  If there is
+// still room, it also passes on any wake-up to the next waiting Push.
+// The lock must be held.
+func (q *ConcurrentQueue[T]) pushed() {
+	signal(q.notEmpty)
+	if q.capacity > 0 && q.hasRoom() {
+		signal(q.notFull)
+	}
+}
+
+// popped wakes one waiting Push, after an item has been popped.  If there
+// are still items, it also passes on any wake-up to the next waiting Pop.
+// The lock must be held.
+func (q *ConcurrentQueue[T]) popped() {
+	if q.capacity > 0 {
+		signal(q.notFull)
+	}
+	if q.items.Len() > 0 {
+		signal(q.notEmpty)
+	}
+}
+
+// wait releases the lock, which must be held, and then waits until it is
+// woken by the given signal, the queue is closed, or the context is done.
+// A waiter that is woken must check again whether it can go ahead.
+func (q *ConcurrentQueue[T]) wait(ctx context.Context, wake <-chan struct{}) error {
+	q.mu.Unlock()
+
+	select {
+	case <-wake:
+		return nil
+	case <-q.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// signal wakes one of the goroutines waiting on the given channel, or if
+// none are waiting, leaves a wake-up for the next one.
+func signal(c chan<- struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,169 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentQueue_producerConsumer(t *testing.T) {
+	q := NewConcurrentQueue[int](4)
+	const n = 1000
+
+	var wg sync.WaitGroup
+	for p := 0; p < 2; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := p; i < n; i += 2 {
+				if err := q.Push(context.Background(), i); err != nil {
+					t.Error("unexpected error", err)
+					return
+				}
+			}
+		}(p)
+	}
+	go func() {
+		wg.Wait()
+		q.Close()
+	}()
+
+	sum, count := 0, 0
+	for {
+		x, err := q.Pop(context.Background())
+		if errors.Is(err, ErrClosed) {
+			break
+		}
+		if err != nil {
+			t.Fatal("unexpected error", err)
+		}
+		if l := q.Len(); l > 4 {
+			t.Errorf("q.Len() = %d ; want at most 4", l)
+		}
+		sum += x
+		count++
+	}
+
+	if count != n || sum != n*(n-1)/2 {
+		t.Logf("popped %d items with sum %d ; want %d with sum %d", count, sum, n, n*(n-1)/2)
+		t.Fail()
+	}
+}
+
+func TestConcurrentQueue_manyConsumers(t *testing.T) {
+	q := NewConcurrentQueue[int](2)
+	const n, workers = 4000, 4
+
+	var producers sync.WaitGroup
+	for p := 0; p < workers; p++ {
+		producers.Add(1)
+		go func(p int) {
+			defer producers.Done()
+			for i := p; i < n; i += workers {
+				if err := q.Push(context.Background(), i); err != nil {
+					t.Error("unexpected error", err)
+					return
+				}
+			}
+		}(p)
+	}
+	go func() {
+		producers.Wait()
+		q.Close()
+	}()
+
+	// each consumer is only woken when there may be an item for it, so they
+	// must pass the wake-ups on to each other for none of them to be stuck:
+	var (
+		consumers sync.WaitGroup
+		mu        sync.Mutex
+		count     int
+	)
+	for c := 0; c < workers; c++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				if _, err := q.Pop(context.Background()); err != nil {
+					return
+				}
+				mu.Lock()
+				count++
+				mu.Unlock()
+			}
+		}()
+	}
+	consumers.Wait()
+
+	if count != n {
+		t.Logf("popped %d items ; want %d", count, n)
+		t.Fail()
+	}
+}
+
+func TestConcurrentQueue_Close(t *testing.T) {
+	q := NewConcurrentQueue[string](0)
+	q.Push(context.Background(), "a")
+	q.Close()
+	q.Close()
+
+	if err := q.Push(context.Background(), "b"); !errors.Is(err, ErrClosed) {
+		t.Logf("q.Push() on a closed queue = %v ; want %v", err, ErrClosed)
+		t.Fail()
+	}
+	if q.TryPush("b") {
+		t.Log("q.TryPush() on a closed queue should return false")
+		t.Fail()
+	}
+
+	if got, err := q.Pop(context.Background()); got != "a" || err != nil {
+		t.Logf("q.Pop() = %q, %v ; want %q, nil", got, err, "a")
+		t.Fail()
+	}
+	if _, err := q.Pop(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Logf("q.Pop() on a closed, empty queue = %v ; want %v", err, ErrClosed)
+		t.Fail()
+	}
+}
+
+func TestConcurrentQueue_cancelled(t *testing.T) {
+	q := NewConcurrentQueue[int](1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := q.Pop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Logf("q.Pop() on an empty queue = %v ; want %v", err, context.DeadlineExceeded)
+		t.Fail()
+	}
+
+	if !q.TryPush(1) {
+		t.Log("q.TryPush() should succeed when there is room")
+		t.Fail()
+	}
+	if q.TryPush(2) {
+		t.Log("q.TryPush() should fail when the queue is full")
+		t.Fail()
+	}
+	if err := q.Push(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Logf("q.Push() on a full queue = %v ; want %v", err, context.DeadlineExceeded)
+		t.Fail()
+	}
+}
+
+func TestConcurrentQueue_TryPop(t *testing.T) {
+	q := NewConcurrentQueue[int](0)
+
+	if _, ok := q.TryPop(); ok {
+		t.Log("q.TryPop() on an empty queue should return false")
+		t.Fail()
+	}
+
+	q.Push(context.Background(), 42)
+	if got, ok := q.TryPop(); got != 42 || !ok {
+		t.Logf("q.TryPop() = %d, %v ; want %d, %v", got, ok, 42, true)
+		t.Fail()
+	}
+}
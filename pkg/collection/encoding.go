@@ -0,0 +1,107 @@
+package collection
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// The containers are encoded as JSON arrays of their items.  Their text
+// encoding is the same as their JSON encoding, so that they can also be used
+// wherever an encoding.TextMarshaler is expected.
+
+// MarshalJSON implements json.Marshaler.
+// The elements are sorted by their encoding, so that equal sets always have
+// the same encoding.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	items := make([]json.RawMessage, 0, len(s))
+	for k := range s {
+		b, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, b)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return bytes.Compare(items[i], items[j]) < 0
+	})
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Any previous elements will be lost.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	s.Init(items)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Set[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Set[T]) UnmarshalText(text []byte) error {
+	return s.UnmarshalJSON(text)
+}
+
+// MarshalJSON implements json.Marshaler.
+// The items are written from front to back.
+func (q Queue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(q.Slice())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Any previous items will be lost.
+func (q *Queue[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	*q = *NewQueue(items...)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (q Queue[T]) MarshalText() ([]byte, error) {
+	return q.MarshalJSON()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (q *Queue[T]) UnmarshalText(text []byte) error {
+	return q.UnmarshalJSON(text)
+}
+
+// MarshalJSON implements json.Marshaler.
+// The items are written from the bottom of the stack to the top.
+func (s Stack[T]) MarshalJSON() ([]byte, error) {
+	if s.data == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Any previous items will be lost.
+func (s *Stack[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	s.data = items
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Stack[T]) MarshalText() ([]byte, error) {
+	return s.MarshalJSON()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Stack[T]) UnmarshalText(text []byte) error {
+	return s.UnmarshalJSON(text)
+}
@@ -0,0 +1,145 @@
+package collection
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSet_JSON(t *testing.T) {
+	t.Parallel()
+
+	s := NewSet("pear", "apple", "fig")
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `["apple","fig","pear"]`; got != want {
+		t.Logf("json.Marshal() = %s ; want %s", got, want)
+		t.Fail()
+	}
+
+	var got Set[string]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(s) {
+		t.Logf("round trip gave %v ; want %v", got, s)
+		t.Fail()
+	}
+
+	var empty Set[int]
+	if data, _ := json.Marshal(empty); string(data) != "[]" {
+		t.Logf("json.Marshal() of an empty set = %s ; want []", data)
+		t.Fail()
+	}
+}
+
+func TestQueue_JSON(t *testing.T) {
+	t.Parallel()
+
+	// start with the items wrapped around the end of the ring buffer:
+	q := NewQueue(0, 0, 1, 2)
+	q.Pop()
+	q.Pop()
+	q.Push(3)
+	q.Push(4)
+
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `[1,2,3,4]`; got != want {
+		t.Logf("json.Marshal() = %s ; want %s", got, want)
+		t.Fail()
+	}
+
+	var got Queue[int]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Slice(), q.Slice()) {
+		t.Logf("round trip gave %v ; want %v", got.Slice(), q.Slice())
+		t.Fail()
+	}
+}
+
+func TestStack_JSON(t *testing.T) {
+	t.Parallel()
+
+	var s Stack[string]
+	s.PushAll("a", "b", "c")
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `["a","b","c"]`; got != want {
+		t.Logf("json.Marshal() = %s ; want %s", got, want)
+		t.Fail()
+	}
+
+	var got Stack[string]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if top, _ := got.Peek(); top != "c" || got.Len() != 3 {
+		t.Logf("round trip gave a stack of %d with %q on top ; want 3 with %q", got.Len(), top, "c")
+		t.Fail()
+	}
+}
+
+// snapshot is an example of search state that includes containers.
+type snapshot struct {
+	Seen     Set[int]
+	Frontier Queue[int]
+	Path     Stack[int]
+}
+
+func TestContainers_snapshot(t *testing.T) {
+	t.Parallel()
+
+	want := snapshot{Seen: NewSet(3, 1, 2)}
+	want.Frontier.Push(4)
+	want.Path.PushAll(1, 2)
+
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := string(data), `{"Seen":[1,2,3],"Frontier":[4],"Path":[1,2]}`; got != exp {
+		t.Logf("json.Marshal() = %s ; want %s", got, exp)
+		t.Fail()
+	}
+
+	var got snapshot
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Seen.Equal(want.Seen) ||
+		!reflect.DeepEqual(got.Frontier.Slice(), want.Frontier.Slice()) ||
+		!reflect.DeepEqual(got.Path.data, want.Path.data) {
+		t.Logf("round trip of %s gave %+v", data, got)
+		t.Fail()
+	}
+}
+
+func TestSet_Text(t *testing.T) {
+	t.Parallel()
+
+	text, err := NewSet(2, 1).MarshalText()
+	if err != nil || string(text) != "[1,2]" {
+		t.Logf("MarshalText() = %s, %v ; want [1,2], nil", text, err)
+		t.Fail()
+	}
+
+	var s Set[int]
+	if err := s.UnmarshalText([]byte("[5,6]")); err != nil || !s.Equal(NewSet(5, 6)) {
+		t.Logf("UnmarshalText() gave %v, %v", s, err)
+		t.Fail()
+	}
+	if err := s.UnmarshalText([]byte("{")); err == nil {
+		t.Log("UnmarshalText() of bad input should return an error")
+		t.Fail()
+	}
+}
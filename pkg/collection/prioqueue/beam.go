@@ -0,0 +1,152 @@
+package prioqueue
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// Beam is a bounded priority container, which keeps only the best items
+// pushed on to it.  Once it holds width items, pushing a better item evicts
+// the worst one, in O(log n) time, and pushing a worse item does nothing.
+// This suits searches that prune each layer of their frontier to a fixed
+// size (a beam search).
+//
+// Items are compared using an Order, where the item that would be popped
+// first from an OrderedQueue is the best.  Ties are broken in favour of the
+// item that was pushed first.
+type Beam[T, P any] struct {
+	h     beamHeap[T, P]
+	width int
+}
+
+// NewBeam creates an empty beam that keeps the best width items, in the
+// given order.  A width of zero or less means that the beam is unbounded.
+func NewBeam[T, P any](width int, order Order[P]) *Beam[T, P] {
+	return &Beam[T, P]{
+		h:     beamHeap[T, P]{order: order},
+		width: width,
+	}
+}
+
+// Len returns the number of items in the beam.
+func (b *Beam[T, P]) Len() int { return len(b.h.nodes) }
+
+// Width returns the largest number of items the beam will hold, or zero if
+// it is unbounded.
+func (b *Beam[T, P]) Width() int {
+	if b.width < 0 {
+		return 0
+	}
+	return b.width
+}
+
+// Push offers the value to the beam with the given priority.  It returns
+// the value's node, which can be passed to Update(), or nil if the value
+// was not good enough to keep.
+func (b *Beam[T, P]) Push(v T, priority P) *Node[T, P] {
+	n := &Node[T, P]{Value: v, Priority: priority, seq: b.h.seq}
+	b.h.seq++
+
+	if b.width <= 0 || len(b.h.nodes) < b.width {
+		heap.Push(&b.h, n)
+		return n
+	}
+
+	if !b.h.better(n, b.h.nodes[0]) {
+		return nil
+	}
+
+	// replace the worst node:
+	b.h.nodes[0].index = -1
+	n.index = 0
+	b.h.nodes[0] = n
+	heap.Fix(&b.h, 0)
+	return n
+}
+
+// Update modifies the value and priority of a node in the beam.  It returns
+// false if the node has already been evicted (or drained), in which case the
+// beam is unchanged; push the value again instead.
+func (b *Beam[T, P]) Update(item *Node[T, P], v T, priority P) bool {
+	if item.index < 0 || item.index >= len(b.h.nodes) || b.h.nodes[item.index] != item {
+		return false
+	}
+	item.Value = v
+	item.Priority = priority
+	heap.Fix(&b.h, item.index)
+	return true
+}
+
+// Worst returns the worst value in the beam, which is the next to be
+// evicted, and its priority.  The bool is false iff the beam is empty.
+func (b *Beam[T, P]) Worst() (T, P, bool) {
+	if len(b.h.nodes) == 0 {
+		var zero T
+		var none P
+		return zero, none, false
+	}
+	return b.h.nodes[0].Value, b.h.nodes[0].Priority, true
+}
+
+// Drain empties the beam, and returns its nodes with the best first.
+func (b *Beam[T, P]) Drain() []*Node[T, P] {
+	nodes := b.h.nodes
+	b.h.nodes = nil
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return b.h.better(nodes[i], nodes[j])
+	})
+	for _, n := range nodes {
+		n.index = -1
+	}
+	return nodes
+}
+
+// beamHeap implements heap.Interface with the worst node at the root.
+type beamHeap[T, P any] struct {
+	nodes []*Node[T, P]
+	order Order[P]
+	seq   uint64 // the number of nodes pushed so far
+}
+
+// better reports whether node a is better than node b.
+func (h *beamHeap[T, P]) better(a, b *Node[T, P]) bool {
+	switch {
+	case h.order(a.Priority, b.Priority):
+		return true
+	case h.order(b.Priority, a.Priority):
+		return false
+	default:
+		return a.seq < b.seq
+	}
+}
+
+// Len implements heap.Interface.
+func (h *beamHeap[T, P]) Len() int { return len(h.nodes) }
+
+// Less implements heap.Interface, so that the worst node is at the root.
+func (h *beamHeap[T, P]) Less(i, j int) bool { return h.better(h.nodes[j], h.nodes[i]) }
+
+// Swap implements heap.Interface.
+func (h *beamHeap[T, P]) Swap(i, j int) {
+	h.nodes[i], h.nodes[j] = h.nodes[j], h.nodes[i]
+	h.nodes[i].index = i
+	h.nodes[j].index = j
+}
+
+// Push implements heap.Interface.
+func (h *beamHeap[T, P]) Push(x interface{}) {
+	n := x.(*Node[T, P])
+	n.index = len(h.nodes)
+	h.nodes = append(h.nodes, n)
+}
+
+// Pop implements heap.Interface.
+func (h *beamHeap[T, P]) Pop() interface{} {
+	n := len(h.nodes)
+	item := h.nodes[n-1]
+	h.nodes[n-1] = nil // avoid memory leak
+	item.index = -1    // for safety
+	h.nodes = h.nodes[:n-1]
+	return item
+}
@@ -0,0 +1,74 @@
+package prioqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeam(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		width int
+		want  []string
+	}{
+		{name: "keeps the best", width: 3, want: []string{"f", "b", "e"}},
+		{name: "unbounded", width: 0, want: []string{"f", "b", "e", "a", "d", "c"}},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b := NewBeam[string](tc.width, MaxFirst[int])
+			b.Push("a", 3)
+			b.Push("b", 8)
+			b.Push("c", 1)
+			b.Push("d", 3)
+			b.Push("e", 5)
+			b.Push("f", 9)
+
+			got := make([]string, 0, len(tc.want))
+			for _, n := range b.Drain() {
+				got = append(got, n.Value)
+			}
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, 0, b.Len())
+		})
+	}
+}
+
+func TestBeam_evictAndUpdate(t *testing.T) {
+	t.Parallel()
+
+	a := assert.New(t)
+	b := NewBeam[string](2, MinFirst[int])
+
+	x := b.Push("x", 5)
+	require.NotNil(t, x)
+	b.Push("y", 3)
+	a.Nil(b.Push("z", 7), "worse than everything in a full beam")
+
+	worst, prio, ok := b.Worst()
+	a.True(ok)
+	a.Equal("x", worst)
+	a.Equal(5, prio)
+
+	a.True(b.Update(x, "x", 1))
+	worst, _, _ = b.Worst()
+	a.Equal("y", worst)
+
+	a.NotNil(b.Push("w", 2)) // evicts y
+	a.Equal(2, b.Len())
+	a.Equal(2, b.Width())
+
+	nodes := b.Drain()
+	require.Len(t, nodes, 2)
+	a.Equal("x", nodes[0].Value)
+	a.Equal("w", nodes[1].Value)
+	a.False(b.Update(x, "x", 0), "drained nodes cannot be updated")
+}
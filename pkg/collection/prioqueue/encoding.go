@@ -0,0 +1,54 @@
+package prioqueue
+
+import (
+	"container/heap"
+	"encoding/json"
+	"errors"
+)
+
+// MarshalJSON implements json.Marshaler.
+// The queue is written as an array of nodes, each with its Value and
+// Priority, in the order that they would be popped.  The queue is not
+// changed: the nodes are popped from a copy of it.
+func (pq Queue[T, P]) MarshalJSON() ([]byte, error) {
+	clone := make(Queue[T, P], len(pq))
+	for i, n := range pq {
+		c := *n
+		clone[i] = &c
+	}
+
+	nodes := make([]*Node[T, P], 0, len(pq))
+	for clone.Len() > 0 {
+		nodes = append(nodes, heap.Pop(&clone).(*Node[T, P]))
+	}
+	return json.Marshal(nodes)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Any previous nodes will be lost.
+func (pq *Queue[T, P]) UnmarshalJSON(data []byte) error {
+	var nodes []*Node[T, P]
+	if err := json.Unmarshal(data, &nodes); err != nil {
+		return err
+	}
+	for i, n := range nodes {
+		if n == nil {
+			return errors.New("prioqueue: cannot unmarshal a null node")
+		}
+		n.index = i
+	}
+	*pq = nodes
+	heap.Init(pq)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// The text encoding is the same as the JSON encoding.
+func (pq Queue[T, P]) MarshalText() ([]byte, error) {
+	return pq.MarshalJSON()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (pq *Queue[T, P]) UnmarshalText(text []byte) error {
+	return pq.UnmarshalJSON(text)
+}
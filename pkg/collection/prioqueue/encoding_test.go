@@ -0,0 +1,94 @@
+package prioqueue
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue_JSON(t *testing.T) {
+	t.Parallel()
+
+	q := new(Queue[string, int])
+	q.Enqueue("b", 2)
+	q.Enqueue("c", 3)
+	q.Enqueue("a", 1)
+
+	data, err := json.Marshal(q)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"Value": "c", "Priority": 3},
+		{"Value": "b", "Priority": 2},
+		{"Value": "a", "Priority": 1}
+	]`, string(data))
+	assert.Equal(t, 3, q.Len(), "marshalling should not change the queue")
+
+	var got Queue[string, int]
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"Value": "a", "Priority": 1},
+		{"Value": "c", "Priority": 3},
+		{"Value": "b", "Priority": 2}
+	]`), &got))
+
+	popped := []string{}
+	for {
+		v, _, ok := got.Dequeue()
+		if !ok {
+			break
+		}
+		popped = append(popped, v)
+	}
+	assert.Equal(t, []string{"c", "b", "a"}, popped)
+
+	assert.Error(t, json.Unmarshal([]byte(`[null]`), &got))
+}
+
+func TestQueue_JSON_ties(t *testing.T) {
+	t.Parallel()
+
+	q := new(Queue[string, int])
+	for _, v := range []string{"a", "b", "c", "d", "e", "f"} {
+		q.Enqueue(v, 1)
+	}
+	q.Enqueue("g", 2)
+
+	data, err := json.Marshal(q)
+	require.NoError(t, err)
+
+	var nodes []Node[string, int]
+	require.NoError(t, json.Unmarshal(data, &nodes))
+
+	// nodes with the same priority are written in the order that they
+	// would be popped:
+	popped := make([]Node[string, int], 0, q.Len())
+	for {
+		v, p, ok := q.Dequeue()
+		if !ok {
+			break
+		}
+		popped = append(popped, Node[string, int]{Value: v, Priority: p})
+	}
+	assert.Equal(t, popped, nodes)
+}
+
+func TestQueue_Text(t *testing.T) {
+	t.Parallel()
+
+	q := new(Queue[int, float64])
+	q.Enqueue(7, 0.5)
+	q.Enqueue(9, 1.5)
+
+	text, err := q.MarshalText()
+	require.NoError(t, err)
+
+	var got Queue[int, float64]
+	require.NoError(t, got.UnmarshalText(text))
+
+	v, p, ok := got.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 9, v)
+	assert.Equal(t, 1.5, p)
+	assert.Equal(t, 2, got.Len())
+}
@@ -0,0 +1,79 @@
+package prioqueue
+
+import "container/heap"
+
+// KeyedQueue is a priority queue of distinct keys.  It keeps track of where
+// each key is in the queue, so that callers can change the priority of a
+// key (decrease-key) without holding on to its Node.
+type KeyedQueue[K comparable, P any] struct {
+	q     OrderedQueue[K, P]
+	nodes map[K]*Node[K, P]
+}
+
+// NewKeyed creates an empty keyed queue that pops keys in the given order.
+func NewKeyed[K comparable, P any](order Order[P]) *KeyedQueue[K, P] {
+	return &KeyedQueue[K, P]{
+		q:     OrderedQueue[K, P]{order: order},
+		nodes: make(map[K]*Node[K, P]),
+	}
+}
+
+// Len returns the number of keys in the queue.
+func (kq *KeyedQueue[K, P]) Len() int { return kq.q.Len() }
+
+// PushOrUpdate adds the key to the queue with the given priority, or if the
+// key is already in the queue, changes its priority.
+func (kq *KeyedQueue[K, P]) PushOrUpdate(key K, priority P) {
+	if n, ok := kq.nodes[key]; ok {
+		kq.q.Update(n, key, priority)
+		return
+	}
+
+	n := &Node[K, P]{Value: key, Priority: priority}
+	kq.nodes[key] = n
+	heap.Push(&kq.q, n)
+}
+
+// Contains reports whether the key is in the queue.
+func (kq *KeyedQueue[K, P]) Contains(key K) bool {
+	_, ok := kq.nodes[key]
+	return ok
+}
+
+// Priority returns the priority of the key, and true iff it is in the queue.
+func (kq *KeyedQueue[K, P]) Priority(key K) (P, bool) {
+	n, ok := kq.nodes[key]
+	if !ok {
+		var none P
+		return none, false
+	}
+	return n.Priority, true
+}
+
+// Remove takes the key out of the queue, and returns true iff it was there.
+func (kq *KeyedQueue[K, P]) Remove(key K) bool {
+	n, ok := kq.nodes[key]
+	if !ok {
+		return false
+	}
+	heap.Remove(&kq.q, n.index)
+	delete(kq.nodes, key)
+	return true
+}
+
+// Pop removes the first key from the queue, and returns it with its
+// priority.  The bool is false iff the queue was empty.
+func (kq *KeyedQueue[K, P]) Pop() (K, P, bool) {
+	key, prio, ok := kq.q.Dequeue()
+	if !ok {
+		return key, prio, false
+	}
+	delete(kq.nodes, key)
+	return key, prio, true
+}
+
+// Peek returns the first key in the queue, and its priority, without
+// removing it.  The bool is false iff the queue is empty.
+func (kq *KeyedQueue[K, P]) Peek() (K, P, bool) {
+	return kq.q.Peek()
+}
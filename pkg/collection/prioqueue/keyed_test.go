@@ -0,0 +1,44 @@
+package prioqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedQueue(t *testing.T) {
+	t.Parallel()
+
+	a := assert.New(t)
+	q := NewKeyed[string](MinFirst[int])
+
+	q.PushOrUpdate("a", 5)
+	q.PushOrUpdate("b", 3)
+	q.PushOrUpdate("c", 4)
+	q.PushOrUpdate("a", 1) // decrease-key
+	a.Equal(3, q.Len())
+	a.True(q.Contains("a"))
+	a.False(q.Contains("z"))
+
+	prio, ok := q.Priority("a")
+	a.True(ok)
+	a.Equal(1, prio)
+
+	a.True(q.Remove("b"))
+	a.False(q.Remove("b"))
+	a.Equal(2, q.Len())
+
+	key, prio, ok := q.Pop()
+	a.True(ok)
+	a.Equal("a", key)
+	a.Equal(1, prio)
+	a.False(q.Contains("a"))
+
+	key, prio, ok = q.Pop()
+	a.True(ok)
+	a.Equal("c", key)
+	a.Equal(4, prio)
+
+	_, _, ok = q.Pop()
+	a.False(ok)
+}
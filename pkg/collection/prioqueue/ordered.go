@@ -0,0 +1,150 @@
+package prioqueue
+
+import "container/heap"
+
+// Ordered is the set of types whose values can be compared with < and >.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Order reports whether a node with priority a should be popped before a
+// node with priority b.
+type Order[P any] func(a, b P) bool
+
+// MaxFirst pops the largest priority first, like Queue.
+func MaxFirst[P Ordered](a, b P) bool { return a > b }
+
+// MinFirst pops the smallest priority first, which suits searches that
+// use the cost so far as the priority.
+func MinFirst[P Ordered](a, b P) bool { return a < b }
+
+// Pair is a priority made of two parts.  The Minor part breaks ties
+// between priorities with the same Major part.
+type Pair[A, B any] struct {
+	Major A
+	Minor B
+}
+
+// ThenBy orders pairs lexicographically: first by their Major parts using
+// major, and then by their Minor parts using minor.  For example, an A*
+// search can pop the lowest f-cost first, and then the highest g-cost:
+//
+//	prioqueue.ThenBy(prioqueue.MinFirst[int], prioqueue.MaxFirst[int])
+func ThenBy[A, B any](major Order[A], minor Order[B]) Order[Pair[A, B]] {
+	return func(a, b Pair[A, B]) bool {
+		switch {
+		case major(a.Major, b.Major):
+			return true
+		case major(b.Major, a.Major):
+			return false
+		default:
+			return minor(a.Minor, b.Minor)
+		}
+	}
+}
+
+// OrderedQueue is a generic priority queue that pops items in the given
+// Order.  Items whose priorities are tied are popped in the order they were
+// pushed, so the results do not depend on the layout of the heap.
+//
+// Like Queue, it has Enqueue(), Dequeue() and Peek() methods, and also
+// implements heap.Interface:
+//
+//	q := prioqueue.New[twod.Point](prioqueue.MinFirst[int])
+//	q.Enqueue(p, cost)
+//	p, cost, ok := q.Dequeue()
+type OrderedQueue[T, P any] struct {
+	nodes []*Node[T, P]
+	order Order[P]
+	seq   uint64 // the number of nodes pushed so far
+}
+
+// New creates an empty queue that pops items in the given order.
+func New[T, P any](order Order[P]) *OrderedQueue[T, P] {
+	return &OrderedQueue[T, P]{order: order}
+}
+
+// Len implements heap.Interface.
+func (pq *OrderedQueue[T, P]) Len() int { return len(pq.nodes) }
+
+// Less implements heap.Interface using the queue's order, and then the
+// order in which the nodes were pushed.
+func (pq *OrderedQueue[T, P]) Less(i, j int) bool {
+	a, b := pq.nodes[i], pq.nodes[j]
+	switch {
+	case pq.order(a.Priority, b.Priority):
+		return true
+	case pq.order(b.Priority, a.Priority):
+		return false
+	default:
+		return a.seq < b.seq
+	}
+}
+
+// Swap implements heap.Interface.
+func (pq *OrderedQueue[T, P]) Swap(i, j int) {
+	pq.nodes[i], pq.nodes[j] = pq.nodes[j], pq.nodes[i]
+	pq.nodes[i].index = i
+	pq.nodes[j].index = j
+}
+
+// Push implements heap.Interface.
+// Do not use this method to push an item on to the queue. Instead, use heap.Push().
+func (pq *OrderedQueue[T, P]) Push(x interface{}) {
+	item := x.(*Node[T, P])
+	item.index = len(pq.nodes)
+	item.seq = pq.seq
+	pq.seq++
+	pq.nodes = append(pq.nodes, item)
+}
+
+// Pop implements heap.Interface.
+// Do not use this method to pop an item off the queue. Instead, use heap.Pop().
+func (pq *OrderedQueue[T, P]) Pop() interface{} {
+	n := len(pq.nodes)
+	item := pq.nodes[n-1]
+	pq.nodes[n-1] = nil // avoid memory leak
+	item.index = -1     // for safety
+	pq.nodes = pq.nodes[:n-1]
+	return item
+}
+
+// Update modifies the priority and value of an item in the queue.
+func (pq *OrderedQueue[T, P]) Update(item *Node[T, P], v T, priority P) {
+	item.Value = v
+	item.Priority = priority
+	heap.Fix(pq, item.index)
+}
+
+// Enqueue pushes the value on to the queue with the given priority, and
+// returns its node, which can be passed to Update().
+func (pq *OrderedQueue[T, P]) Enqueue(v T, priority P) *Node[T, P] {
+	n := &Node[T, P]{Value: v, Priority: priority}
+	heap.Push(pq, n)
+	return n
+}
+
+// Dequeue pops the first value off the queue, and returns it with its
+// priority.  The bool is false iff the queue was empty.
+func (pq *OrderedQueue[T, P]) Dequeue() (T, P, bool) {
+	if len(pq.nodes) == 0 {
+		var zero T
+		var none P
+		return zero, none, false
+	}
+	n := heap.Pop(pq).(*Node[T, P])
+	return n.Value, n.Priority, true
+}
+
+// Peek returns the first value in the queue, and its priority, without
+// removing it.  The bool is false iff the queue is empty.
+func (pq *OrderedQueue[T, P]) Peek() (T, P, bool) {
+	if len(pq.nodes) == 0 {
+		var zero T
+		var none P
+		return zero, none, false
+	}
+	return pq.nodes[0].Value, pq.nodes[0].Priority, true
+}
@@ -0,0 +1,143 @@
+package prioqueue
+
+import (
+	"container/heap"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedQueue(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		order Order[int]
+		want  []int
+	}{
+		{name: "min first", order: MinFirst[int], want: []int{-5, 0, 3, 7, 42}},
+		{name: "max first", order: MaxFirst[int], want: []int{42, 7, 3, 0, -5}},
+		{
+			name:  "custom: nearest to zero first",
+			order: func(a, b int) bool { return a*a < b*b },
+			want:  []int{0, 3, -5, 7, 42},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := New[string](tc.order)
+			for _, p := range []int{3, 42, -5, 7, 0} {
+				heap.Push(q, &Node[string, int]{Priority: p})
+			}
+
+			got := make([]int, 0, len(tc.want))
+			for q.Len() > 0 {
+				got = append(got, heap.Pop(q).(*Node[string, int]).Priority)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestOrderedQueue_Update(t *testing.T) {
+	t.Parallel()
+
+	q := New[string](MinFirst[int])
+	a := &Node[string, int]{Value: "a", Priority: 1}
+	b := &Node[string, int]{Value: "b", Priority: 2}
+	heap.Push(q, a)
+	heap.Push(q, b)
+
+	q.Update(b, "b", 0)
+
+	got := heap.Pop(q).(*Node[string, int])
+	assert.Equal(t, "b", got.Value)
+}
+
+func TestOrderedQueue_enqueueAndDequeue(t *testing.T) {
+	t.Parallel()
+
+	a := assert.New(t)
+	q := New[string](MinFirst[int])
+	q.Enqueue("b", 2)
+	q.Enqueue("a", 1)
+
+	val, prio, ok := q.Peek()
+	a.True(ok)
+	a.Equal("a", val)
+	a.Equal(1, prio)
+
+	val, _, _ = q.Dequeue()
+	a.Equal("a", val)
+	val, _, _ = q.Dequeue()
+	a.Equal("b", val)
+	_, _, ok = q.Dequeue()
+	a.False(ok)
+}
+
+func TestOrderedQueue_float(t *testing.T) {
+	t.Parallel()
+
+	q := New[string](MinFirst[float64])
+	q.Enqueue("b", 0.25)
+	q.Enqueue("c", 1.5)
+	q.Enqueue("a", -0.5)
+
+	var got []string
+	for q.Len() > 0 {
+		val, _, _ := q.Dequeue()
+		got = append(got, val)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, got)
+}
+
+func TestOrderedQueue_ties(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		order Order[Pair[int, int]]
+		want  []string
+	}{
+		{
+			name:  "lowest f then highest g",
+			order: ThenBy(MinFirst[int], MaxFirst[int]),
+			want:  []string{"d", "b", "e", "c", "a"},
+		},
+		{
+			name:  "lowest f then lowest g",
+			order: ThenBy(MinFirst[int], MinFirst[int]),
+			want:  []string{"d", "c", "e", "b", "a"},
+		},
+		{
+			name:  "lowest f, then insertion order",
+			order: func(a, b Pair[int, int]) bool { return a.Major < b.Major },
+			want:  []string{"d", "b", "c", "e", "a"},
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := New[string](tc.order)
+			q.Enqueue("a", Pair[int, int]{Major: 9, Minor: 0})
+			q.Enqueue("b", Pair[int, int]{Major: 5, Minor: 4})
+			q.Enqueue("c", Pair[int, int]{Major: 5, Minor: 1})
+			q.Enqueue("d", Pair[int, int]{Major: 3, Minor: 3})
+			q.Enqueue("e", Pair[int, int]{Major: 5, Minor: 2})
+
+			got := make([]string, 0, len(tc.want))
+			for q.Len() > 0 {
+				val, _, _ := q.Dequeue()
+				got = append(got, val)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -4,48 +4,55 @@ import "container/heap"
 
 // Queue is a generic priority queue.  After items are pushed on to
 // the queue, they will be popped off with largest priority first.
+// The priorities may be any ordered type, such as int or float64.
 //
 // The zero value is ready to use:
-//    q := new(Queue)
+//    q := new(Queue[twod.Point, int])
 //
 // An existing slice can be converted to a priority Queue:
-//    nodes := []*Node[int] {
-//        {Value: 1, Priority: 1},
-//        {Value: 2, Priority: 2},
-//        {Value: 3, Priority: 3}}
-// 	  q := Queue[int](nodes)
+//    nodes := []*Node[string, int] {
+//        {Value: "a", Priority: 1},
+//        {Value: "b", Priority: 2},
+//        {Value: "c", Priority: 3}}
+// 	  q := Queue[string, int](nodes)
 //    heap.Init(&q)
 //
-// Use heap.Push() and heap.Pop() to push and pop items on to the queue:
-//    heap.Push(q, &Node{ Value: twod.Point{X: 1, Y: 2}, Priority: 3})
-//    node, ok := heap.Pop(q).(*Node)
+// Use Enqueue() and Dequeue() to push and pop items on to the queue:
+//    node := q.Enqueue(twod.Point{X: 1, Y: 2}, 3)
+//    value, priority, ok := q.Dequeue()
 //
-// Keep a pointer to a node in the queue, and then use Update()
+// Keep the node returned by Enqueue(), and then use Update()
 // to update the value and/or priority of the node:
-//    heap.Push(q, node)
 //    q.Update(node, node.Value, 4) // keeps the existing value
 //
+// Queue also implements heap.Interface, so heap.Push() and heap.Pop() work too.
+//
+// To pop the smallest priority first, or to use some other order, create an
+// OrderedQueue with New().  To have the queue keep track of each node by its
+// value, use a KeyedQueue.
+//
 // adapted from the example at: https://pkg.go.dev/container/heap
-type Queue[T any] []*Node[T]
+type Queue[T any, P Ordered] []*Node[T, P]
 
 // Node combines a value with its priority.
-type Node[T any] struct {
+type Node[T, P any] struct {
 	Value    T
-	Priority int
+	Priority P
 	index    int
+	seq      uint64 // the order in which the node was pushed (OrderedQueue only)
 }
 
 // Len implements heap.Interface.
-func (pq Queue[T]) Len() int { return len(pq) }
+func (pq Queue[T, P]) Len() int { return len(pq) }
 
 // Less implements heap.Interface using the (negative) priority of the item.
-func (pq Queue[T]) Less(i, j int) bool {
+func (pq Queue[T, P]) Less(i, j int) bool {
 	// we want Pop to give the highest priority, not lowest, so we use greater
 	return pq[i].Priority > pq[j].Priority
 }
 
 // Swap implements heap.Interface.
-func (pq Queue[T]) Swap(i, j int) {
+func (pq Queue[T, P]) Swap(i, j int) {
 	pq[i], pq[j] = pq[j], pq[i]
 	pq[i].index = i
 	pq[j].index = j
@@ -53,16 +60,16 @@ func (pq Queue[T]) Swap(i, j int) {
 
 // Push implements heap.Interface.
 // Do not use this method to push an item on to the queue. Instead, use heap.Push().
-func (pq *Queue[T]) Push(x interface{}) {
+func (pq *Queue[T, P]) Push(x interface{}) {
 	n := len(*pq)
-	item := x.(*Node[T])
+	item := x.(*Node[T, P])
 	item.index = n
 	*pq = append(*pq, item)
 }
 
 // Pop implements heap.Interface.
 // Do not use this method to pop an item off the queue. Instead, use heap.Pop().
-func (pq *Queue[T]) Pop() interface{} {
+func (pq *Queue[T, P]) Pop() interface{} {
 	old := *pq
 	n := len(old)
 	item := old[n-1]
@@ -73,8 +80,39 @@ func (pq *Queue[T]) Pop() interface{} {
 }
 
 // Update modifies the priority and value of an item in the queue.
-func (pq *Queue[T]) Update(item *Node[T], v T, priority int) {
+func (pq *Queue[T, P]) Update(item *Node[T, P], v T, priority P) {
 	item.Value = v
 	item.Priority = priority
 	heap.Fix(pq, item.index)
 }
+
+// Enqueue pushes the value on to the queue with the given priority, and
+// returns its node, which can be passed to Update().
+func (pq *Queue[T, P]) Enqueue(v T, priority P) *Node[T, P] {
+	n := &Node[T, P]{Value: v, Priority: priority}
+	heap.Push(pq, n)
+	return n
+}
+
+// Dequeue pops the value with the highest priority off the queue, and
+// returns it with its priority.  The bool is false iff the queue was empty.
+func (pq *Queue[T, P]) Dequeue() (T, P, bool) {
+	if len(*pq) == 0 {
+		var zero T
+		var none P
+		return zero, none, false
+	}
+	n := heap.Pop(pq).(*Node[T, P])
+	return n.Value, n.Priority, true
+}
+
+// Peek returns the value with the highest priority, and its priority,
+// without removing it.  The bool is false iff the queue is empty.
+func (pq Queue[T, P]) Peek() (T, P, bool) {
+	if len(pq) == 0 {
+		var zero T
+		var none P
+		return zero, none, false
+	}
+	return pq[0].Value, pq[0].Priority, true
+}
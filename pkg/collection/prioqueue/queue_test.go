@@ -12,9 +12,9 @@ import (
 )
 
 func TestQueue_pushAndLen(t *testing.T) {
-	q := make(Queue[v.Point], 0)
+	q := make(Queue[v.Point, int], 0)
 
-	nodes := []*Node[v.Point]{
+	nodes := []*Node[v.Point, int]{
 		{
 			Value:    v.Point{X: 4, Y: 2},
 			Priority: 42,
@@ -37,7 +37,7 @@ func TestQueue_pushAndLen(t *testing.T) {
 }
 
 func TestQueue_initAndPop(t *testing.T) {
-	nodes := []*Node[v.Point]{
+	nodes := []*Node[v.Point, int]{
 		{
 			Value:    v.Point{X: 4, Y: 2},
 			Priority: 42,
@@ -52,18 +52,18 @@ func TestQueue_initAndPop(t *testing.T) {
 		},
 	}
 
-	q := Queue[v.Point](nodes)
+	q := Queue[v.Point, int](nodes)
 	fmt.Println(q)
 
 	heap.Init(&q)
 	fmt.Println(q)
 
-	node, ok := heap.Pop(&q).(*Node[v.Point])
+	node, ok := heap.Pop(&q).(*Node[v.Point, int])
 	require.True(t, ok)
 	assert.Equal(t, v.Point{X: 6, Y: 7}, node.Value)
 	assert.Equal(t, 67, node.Priority)
 
-	node, ok = heap.Pop(&q).(*Node[v.Point])
+	node, ok = heap.Pop(&q).(*Node[v.Point, int])
 	require.True(t, ok)
 	assert.Equal(t, v.Point{X: 4, Y: 2}, node.Value)
 	assert.Equal(t, 42, node.Priority)
@@ -76,19 +76,48 @@ func ExampleQueue_Update() {
 		{X: 0, Y: 0}: 0,
 	}
 
-	q := new(Queue[v.Point])
+	q := new(Queue[v.Point, int])
 	for val, prio := range items {
-		heap.Push(q, &Node[v.Point]{Value: val, Priority: prio})
+		heap.Push(q, &Node[v.Point, int]{Value: val, Priority: prio})
 	}
 
 	// push the item on to the queue (it will have priority 0)
-	newItem := &Node[v.Point]{Value: v.Point{X: 99, Y: 99}}
+	newItem := &Node[v.Point, int]{Value: v.Point{X: 99, Y: 99}}
 	heap.Push(q, newItem)
 
 	// now update the item's priority:
 	q.Update(newItem, newItem.Value, 9999)
 
-	node, _ := heap.Pop(q).(*Node[v.Point])
+	node, _ := heap.Pop(q).(*Node[v.Point, int])
 	fmt.Printf("priority: %2d value: %+v\n", node.Priority, node.Value)
 	// Output: priority: 9999 value: {X:99 Y:99}
 }
+
+func TestQueue_enqueueAndDequeue(t *testing.T) {
+	a := assert.New(t)
+	q := new(Queue[string, int])
+
+	_, _, ok := q.Peek()
+	a.False(ok)
+
+	q.Enqueue("low", 1)
+	high := q.Enqueue("high", 3)
+	q.Enqueue("middle", 2)
+	q.Update(high, high.Value, 0)
+
+	val, prio, ok := q.Peek()
+	a.True(ok)
+	a.Equal("middle", val)
+	a.Equal(2, prio)
+	a.Equal(3, q.Len())
+
+	var got []string
+	for {
+		val, _, ok := q.Dequeue()
+		if !ok {
+			break
+		}
+		got = append(got, val)
+	}
+	a.Equal([]string{"middle", "low", "high"}, got)
+}
@@ -1,34 +1,150 @@
 package collection
 
-// Queue is a generic first-in, first-out container.
+// Queue is a generic first-in, first-out container.  It also supports
+// pushing to the front and popping from the back, so it can be used as a
+// double-ended queue.
 // It is not safe to use concurrently.
+//
+// The items are stored in a ring buffer, which grows as needed, so popping
+// an item takes constant time and does not leak the space it used.
+// The zero value is an empty queue, ready to use.
 type Queue[T any] struct {
-	data []T
+	data  []T // the ring buffer; its length is the capacity of the queue
+	head  int // the index of the front item
+	count int // the number of items in the queue
 }
 
 // NewQueue initializes a new queue using the given data.
+// The queue takes ownership of the slice.
 func NewQueue[T any](data ...T) *Queue[T] {
-	return &Queue[T]{data: data}
+	return &Queue[T]{data: data, count: len(data)}
 }
 
 // Len returns the number of items in this queue.
 func (q *Queue[T]) Len() int {
+	return q.count
+}
+
+// Cap returns the number of items this queue can hold before it has to grow.
+func (q *Queue[T]) Cap() int {
 	return len(q.data)
 }
 
+// Grow makes room for at least n more items, so that pushing them does not
+// need to allocate.
+func (q *Queue[T]) Grow(n int) {
+	if q.count+n > len(q.data) {
+		q.resize(q.count + n)
+	}
+}
+
 // Push the given item to the back of the queue.
 func (q *Queue[T]) Push(x T) {
-	(*q).data = append(q.data, x)
+	if q.count == len(q.data) {
+		q.resize(2 * q.count)
+	}
+	q.data[q.index(q.count)] = x
+	q.count++
+}
+
+// PushFront pushes the given item to the front of the queue, so that it is
+// the next one to be popped.
+func (q *Queue[T]) PushFront(x T) {
+	if q.count == len(q.data) {
+		q.resize(2 * q.count)
+	}
+	q.head = q.index(len(q.data) - 1)
+	q.data[q.head] = x
+	q.count++
 }
 
 // Pop returns the front item from the queue, and true iff there is an item.
 func (q *Queue[T]) Pop() (T, bool) {
-	if len(q.data) == 0 {
-		var zero T
+	var zero T
+	if q.count == 0 {
 		return zero, false
 	}
 
-	v := q.data[0]
-	q.data = q.data[1:]
+	v := q.data[q.head]
+	q.data[q.head] = zero // avoid memory leak
+	q.head = q.index(1)
+	q.count--
 	return v, true
 }
+
+// PopBack returns the back item from the queue, and true iff there is an item.
+func (q *Queue[T]) PopBack() (T, bool) {
+	var zero T
+	if q.count == 0 {
+		return zero, false
+	}
+
+	i := q.index(q.count - 1)
+	v := q.data[i]
+	q.data[i] = zero // avoid memory leak
+	q.count--
+	return v, true
+}
+
+// Peek returns the front item from the queue without removing it, and true
+// iff there is an item.
+func (q *Queue[T]) Peek() (T, bool) {
+	if q.count == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.data[q.head], true
+}
+
+// Clear removes all of the items from the queue, keeping its capacity.
+func (q *Queue[T]) Clear() {
+	var zero T
+	for i := 0; i < q.count; i++ {
+		q.data[q.index(i)] = zero
+	}
+	q.head, q.count = 0, 0
+}
+
+// Each calls f for each item in the queue, from front to back, until f
+// returns false.  The queue must not be changed while Each is running.
+func (q *Queue[T]) Each(f func(x T) bool) {
+	for i := 0; i < q.count; i++ {
+		if !f(q.data[q.index(i)]) {
+			return
+		}
+	}
+}
+
+// Slice returns a copy of the items in the queue, from front to back.
+func (q *Queue[T]) Slice() []T {
+	s := make([]T, 0, q.count)
+	q.Each(func(x T) bool {
+		s = append(s, x)
+		return true
+	})
+	return s
+}
+
+// index returns the position in the ring buffer of the i'th item from the
+// front of the queue.
+func (q *Queue[T]) index(i int) int {
+	return (q.head + i) % len(q.data)
+}
+
+// resize moves the items into a new ring buffer, that can hold at least n
+// items, with the front item at the start.
+func (q *Queue[T]) resize(n int) {
+	if n < 4 {
+		n = 4
+	}
+	data := make([]T, n)
+	if q.count > 0 {
+		if end := q.head + q.count; end <= len(q.data) {
+			copy(data, q.data[q.head:end])
+		} else {
+			k := copy(data, q.data[q.head:])
+			copy(data[k:], q.data[:end-len(q.data)])
+		}
+	}
+	q.data, q.head = data, 0
+}
@@ -1,6 +1,7 @@
 package collection
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -70,3 +71,104 @@ func TestQueue_PopEmpty(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestQueue_wrapAround(t *testing.T) {
+	q := NewQueue(1, 2, 3)
+	want := []int{1, 2, 3}
+
+	// pop and push enough to wrap around the ring buffer, and to grow it
+	// while it is wrapped.
+	for i := 4; i <= 20; i++ {
+		if i%3 == 0 {
+			q.Pop()
+			want = want[1:]
+		}
+		q.Push(i)
+		want = append(want, i)
+	}
+
+	got := q.Slice()
+	if !reflect.DeepEqual(got, want) {
+		t.Logf("q.Slice() = %v ; want %v", got, want)
+		t.Fail()
+	}
+}
+
+func TestQueue_deque(t *testing.T) {
+	q := Queue[string]{}
+
+	q.Push("b")
+	q.PushFront("a")
+	q.Push("c")
+	q.PushFront("z")
+
+	if got, ok := q.Peek(); got != "z" || !ok {
+		t.Logf("q.Peek() = %q, %v ; want %q, %v", got, ok, "z", true)
+		t.Fail()
+	}
+
+	if got, ok := q.PopBack(); got != "c" || !ok {
+		t.Logf("q.PopBack() = %q, %v ; want %q, %v", got, ok, "c", true)
+		t.Fail()
+	}
+
+	want := []string{"z", "a", "b"}
+	if got := q.Slice(); !reflect.DeepEqual(got, want) {
+		t.Logf("q.Slice() = %v ; want %v", got, want)
+		t.Fail()
+	}
+
+	var first []string
+	q.Each(func(s string) bool {
+		first = append(first, s)
+		return len(first) < 2
+	})
+	if !reflect.DeepEqual(first, want[:2]) {
+		t.Logf("q.Each() visited %v ; want %v", first, want[:2])
+		t.Fail()
+	}
+
+	q.Clear()
+	if _, ok := q.PopBack(); ok || q.Len() != 0 {
+		t.Log("a cleared queue should be empty")
+		t.Fail()
+	}
+	if _, ok := q.Peek(); ok {
+		t.Log("peeking at an empty queue should return false")
+		t.Fail()
+	}
+}
+
+func TestQueue_Grow(t *testing.T) {
+	q := NewQueue(1, 2)
+	q.Grow(100)
+
+	if q.Cap() < 102 {
+		t.Logf("q.Cap() = %d ; want at least %d", q.Cap(), 102)
+		t.Fail()
+	}
+
+	capacity := q.Cap()
+	for i := 0; i < 100; i++ {
+		q.Push(i)
+	}
+	if q.Cap() != capacity {
+		t.Logf("q.Cap() = %d after pushing ; want %d", q.Cap(), capacity)
+		t.Fail()
+	}
+}
+
+func TestQueue_PopReleases(t *testing.T) {
+	x, y := new(int), new(int)
+	q := NewQueue(x, y)
+
+	q.Pop()
+	q.PopBack()
+
+	for i, p := range q.data {
+		if p != nil {
+			t.Logf("q.data[%d] still refers to a popped item", i)
+			t.Fail()
+		}
+	}
+}
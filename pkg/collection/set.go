@@ -9,6 +9,7 @@ import (
 // Not safe for concurrent use.
 type Set[T comparable] map[T]struct{}
 
+// NewSet creates a set with the given values.
 func NewSet[T comparable](vals ...T) Set[T] {
 	s := make(Set[T])
 	s.Init(vals)
@@ -40,18 +41,99 @@ func (s Set[T]) Contains(k T) bool {
 	return ok
 }
 
-// Union returns the union of A and B
-func Union[T comparable](a, b Set[T]) Set[T] {
-	res := make(Set[T], len(a))
-	for k := range a {
+// Len returns the number of elements in the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Clone returns a copy of the set.
+func (s Set[T]) Clone() Set[T] {
+	res := make(Set[T], len(s))
+	for k := range s {
 		res[k] = struct{}{}
 	}
-	for k := range b {
-		res[k] = struct{}{}
+	return res
+}
+
+// Filter returns a new set with the elements of s for which keep returns true.
+func (s Set[T]) Filter(keep func(k T) bool) Set[T] {
+	res := make(Set[T])
+	for k := range s {
+		if keep(k) {
+			res[k] = struct{}{}
+		}
 	}
 	return res
 }
 
+// Slice returns the elements of the set, sorted using the given less
+// function.  If less is nil, the order is not defined.
+func (s Set[T]) Slice(less func(a, b T) bool) []T {
+	res := make([]T, 0, len(s))
+	for k := range s {
+		res = append(res, k)
+	}
+	if less != nil {
+		sort.Slice(res, func(i, j int) bool { return less(res[i], res[j]) })
+	}
+	return res
+}
+
+// IsSubset returns true iff every element of s is also in other.
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for k := range s {
+		if _, ok := other[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset returns true iff every element of other is also in s.
+func (s Set[T]) IsSuperset(other Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal returns true iff s and other have the same elements.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// UnionWith adds the elements of each of the other sets to this set.
+func (s *Set[T]) UnionWith(others ...Set[T]) {
+	if *s == nil {
+		*s = make(Set[T])
+	}
+	for _, o := range others {
+		for k := range o {
+			(*s)[k] = struct{}{}
+		}
+	}
+}
+
+// IntersectWith removes the elements of this set that are not in every one
+// of the other sets.
+func (s *Set[T]) IntersectWith(others ...Set[T]) {
+	for k := range *s {
+		for _, o := range others {
+			if _, ok := o[k]; !ok {
+				delete(*s, k)
+				break
+			}
+		}
+	}
+}
+
+// Union returns the union of the given sets.
+func Union[T comparable](sets ...Set[T]) Set[T] {
+	res := make(Set[T])
+	res.UnionWith(sets...)
+	return res
+}
+
 // Difference returns A minus B
 func Difference[T comparable](a, b Set[T]) Set[T] {
 	res := make(Set[T], len(a))
@@ -63,20 +145,38 @@ func Difference[T comparable](a, b Set[T]) Set[T] {
 	return res
 }
 
-// Intersect returns the intersection of A and B
-func Intersect[T comparable](a, b Set[T]) Set[T] {
-	if len(b) < len(a) {
-		a, b = b, a
-	}
-	res := make(Set[T], len(a))
-	for k := range a {
-		if _, ok := b[k]; ok {
+// SymmetricDifference returns the elements that are in either A or B, but
+// not in both.
+func SymmetricDifference[T comparable](a, b Set[T]) Set[T] {
+	res := Difference(a, b)
+	for k := range b {
+		if _, ok := a[k]; !ok {
 			res[k] = struct{}{}
 		}
 	}
 	return res
 }
 
+// Intersect returns the intersection of the given sets.  The intersection
+// of no sets is the empty set.
+func Intersect[T comparable](sets ...Set[T]) Set[T] {
+	if len(sets) == 0 {
+		return make(Set[T])
+	}
+
+	// start from the smallest set, since the result can be no bigger.
+	smallest := 0
+	for i, s := range sets {
+		if len(s) < len(sets[smallest]) {
+			smallest = i
+		}
+	}
+
+	res := sets[smallest].Clone()
+	res.IntersectWith(sets...)
+	return res
+}
+
 // Format implements fmt.Formatter.
 // It writes the elements of s in lexicographic order.
 func (s Set[T]) Format(f fmt.State, verb rune) {
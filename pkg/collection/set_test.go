@@ -1,6 +1,7 @@
 package collection
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -122,3 +123,94 @@ func TestSet_String(t *testing.T) {
 		})
 	}
 }
+
+func TestSet_algebra(t *testing.T) {
+	t.Parallel()
+
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(3, 4, 5)
+	c := NewSet(4, 5, 6)
+	less := func(x, y int) bool { return x < y }
+
+	tt := []struct {
+		name string
+		got  Set[int]
+		want []int
+	}{
+		{name: "union", got: Union(a, b, c), want: []int{1, 2, 3, 4, 5, 6}},
+		{name: "union of nothing", got: Union[int](), want: []int{}},
+		{name: "intersect", got: Intersect(a, b, c), want: []int{4}},
+		{name: "intersect two", got: Intersect(a, b), want: []int{3, 4}},
+		{name: "intersect nothing", got: Intersect[int](), want: []int{}},
+		{name: "difference", got: Difference(a, b), want: []int{1, 2}},
+		{name: "symmetric difference", got: SymmetricDifference(a, b), want: []int{1, 2, 5}},
+		{name: "filter", got: a.Filter(func(k int) bool { return k%2 == 0 }), want: []int{2, 4}},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tc.got.Slice(less)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Logf("got %v ; want %v", got, tc.want)
+				t.Fail()
+			}
+		})
+	}
+
+	if a.Len() != 4 || b.Len() != 3 {
+		t.Log("the operations should not modify their operands")
+		t.Fail()
+	}
+}
+
+func TestSet_inPlace(t *testing.T) {
+	t.Parallel()
+
+	var s Set[string]
+	s.UnionWith(NewSet("a", "b"), NewSet("c"))
+	if want := NewSet("a", "b", "c"); !s.Equal(want) {
+		t.Logf("after UnionWith, s = %v ; want %v", s, want)
+		t.Fail()
+	}
+
+	clone := s.Clone()
+	s.IntersectWith(NewSet("a", "c", "d"), NewSet("c", "a"))
+	if want := NewSet("a", "c"); !s.Equal(want) {
+		t.Logf("after IntersectWith, s = %v ; want %v", s, want)
+		t.Fail()
+	}
+	if clone.Len() != 3 {
+		t.Logf("clone.Len() = %d ; want 3", clone.Len())
+		t.Fail()
+	}
+}
+
+func TestSet_compare(t *testing.T) {
+	t.Parallel()
+
+	small, big := NewSet(1, 2), NewSet(1, 2, 3)
+
+	tt := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{name: "subset", got: small.IsSubset(big), want: true},
+		{name: "not subset", got: big.IsSubset(small), want: false},
+		{name: "superset", got: big.IsSuperset(small), want: true},
+		{name: "not superset", got: small.IsSuperset(big), want: false},
+		{name: "empty is a subset", got: Set[int](nil).IsSubset(small), want: true},
+		{name: "equal", got: small.Equal(NewSet(2, 1)), want: true},
+		{name: "not equal", got: small.Equal(NewSet(1, 3)), want: false},
+	}
+
+	for _, tc := range tt {
+		if tc.got != tc.want {
+			t.Logf("%s: got %t ; want %t", tc.name, tc.got, tc.want)
+			t.Fail()
+		}
+	}
+}
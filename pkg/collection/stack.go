@@ -6,47 +6,43 @@ import (
 )
 
 // Stack is a generic first-in, last-out container.
-// It is safe to use concurrently.
+// It is not safe to use concurrently; use a SyncStack for that.
 type Stack[T any] struct {
-	mu   sync.RWMutex
 	data []T
 }
 
 // Len returns the number of items in this stack.
 func (s *Stack[T]) Len() int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
 	return len(s.data)
 }
 
 // Push the given item to the top of the stack.
 func (s *Stack[T]) Push(x T) {
-	s.mu.Lock()
 	s.data = append(s.data, x)
-	s.mu.Unlock()
+}
+
+// PushAll pushes the given items on to the stack in order, so that the last
+// one is on top.
+func (s *Stack[T]) PushAll(xs ...T) {
+	s.data = append(s.data, xs...)
 }
 
 // Pop returns the top item from the stack, or an error if the stack is empty.
 func (s *Stack[T]) Pop() (T, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
+	var zero T
 	if len(s.data) == 0 {
-		var zero T
 		return zero, errors.New("cannot pop an empty stack")
 	}
 
 	last := len(s.data) - 1
 	v := s.data[last]
+	s.data[last] = zero // avoid memory leak
 	s.data = s.data[:last]
 	return v, nil
 }
 
 // Peek returns the top item from the stack, or an error if the stack is empty.
 func (s *Stack[T]) Peek() (T, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
 	if len(s.data) == 0 {
 		var zero T
 		return zero, errors.New("cannot peek at an empty stack")
@@ -54,3 +50,87 @@ func (s *Stack[T]) Peek() (T, error) {
 
 	return s.data[len(s.data)-1], nil
 }
+
+// Clear removes all of the items from the stack, keeping its capacity.
+func (s *Stack[T]) Clear() {
+	var zero T
+	for i := range s.data {
+		s.data[i] = zero
+	}
+	s.data = s.data[:0]
+}
+
+// Drain pops each item from the stack, from the top down, and calls f with
+// it, until the stack is empty or f returns false.  The function may push
+// more items on to the stack; they will be popped in turn.
+func (s *Stack[T]) Drain(f func(x T) bool) {
+	for len(s.data) > 0 {
+		x, _ := s.Pop()
+		if !f(x) {
+			return
+		}
+	}
+}
+
+// SyncStack is a generic first-in, last-out container.
+// It is safe to use concurrently.
+type SyncStack[T any] struct {
+	mu    sync.RWMutex
+	stack Stack[T]
+}
+
+// Len returns the number of items in this stack.
+func (s *SyncStack[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.stack.Len()
+}
+
+// Push the given item to the top of the stack.
+func (s *SyncStack[T]) Push(x T) {
+	s.mu.Lock()
+	s.stack.Push(x)
+	s.mu.Unlock()
+}
+
+// PushAll pushes the given items on to the stack in order, so that the last
+// one is on top.  No other items are pushed in between them.
+func (s *SyncStack[T]) PushAll(xs ...T) {
+	s.mu.Lock()
+	s.stack.PushAll(xs...)
+	s.mu.Unlock()
+}
+
+// Pop returns the top item from the stack, or an error if the stack is empty.
+func (s *SyncStack[T]) Pop() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Pop()
+}
+
+// Peek returns the top item from the stack, or an error if the stack is empty.
+func (s *SyncStack[T]) Peek() (T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.stack.Peek()
+}
+
+// Clear removes all of the items from the stack.
+func (s *SyncStack[T]) Clear() {
+	s.mu.Lock()
+	s.stack.Clear()
+	s.mu.Unlock()
+}
+
+// Drain pops each item from the stack, from the top down, and calls f with
+// it, until the stack is empty or f returns false.  The lock is not held
+// while f runs, so f may push more items, and other goroutines may push and
+// pop items at the same time.
+func (s *SyncStack[T]) Drain(f func(x T) bool) {
+	for {
+		x, err := s.Pop()
+		if err != nil || !f(x) {
+			return
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package collection
 
 import (
+	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -97,3 +99,80 @@ func TestStack_PeekEmpty(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestStack_PushAllDrain(t *testing.T) {
+	s := Stack[int]{}
+	s.PushAll(1, 2, 3)
+
+	var got []int
+	s.Drain(func(x int) bool {
+		got = append(got, x)
+		if x == 3 {
+			s.Push(4) // pushed while draining
+		}
+		return true
+	})
+
+	want := []int{3, 4, 2, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Logf("s.Drain() visited %v ; want %v", got, want)
+		t.Fail()
+	}
+	if s.Len() != 0 {
+		t.Logf("s.Len() = %d after draining ; want 0", s.Len())
+		t.Fail()
+	}
+}
+
+func TestStack_Clear(t *testing.T) {
+	s := Stack[int]{}
+	s.PushAll(1, 2, 3)
+	s.Clear()
+
+	if _, err := s.Pop(); err == nil || s.Len() != 0 {
+		t.Log("a cleared stack should be empty")
+		t.Fail()
+	}
+}
+
+func TestSyncStack(t *testing.T) {
+	s := SyncStack[int]{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.PushAll(i, j)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if s.Len() != 800 {
+		t.Logf("s.Len() = %d ; want %d", s.Len(), 800)
+		t.Fail()
+	}
+
+	if top, err := s.Peek(); err != nil || top != 99 {
+		t.Logf("s.Peek() = %d, %v ; want %d, nil", top, err, 99)
+		t.Fail()
+	}
+
+	count := 0
+	s.Drain(func(int) bool {
+		count++
+		return count < 10
+	})
+	if count != 10 || s.Len() != 790 {
+		t.Logf("drained %d items, leaving %d ; want 10, leaving 790", count, s.Len())
+		t.Fail()
+	}
+
+	s.Clear()
+	if _, err := s.Pop(); err == nil {
+		t.Log("popping a cleared stack should return an error")
+		t.Fail()
+	}
+}
@@ -0,0 +1,98 @@
+// Package progress lets long-running searches report how they are getting
+// on, and notice when they should stop early.
+//
+// A search creates a Meter from its context, and calls Expand each time it
+// expands a state.  Every so often, the meter sends a Report to the Reporter
+// carried by the context (if any), and checks whether the context has been
+// cancelled.
+package progress
+
+import (
+	"context"
+)
+
+// Report is a snapshot of the progress of a search.
+type Report struct {
+	Stage    string // which search this is, if a solver runs more than one
+	Expanded int    // the number of states expanded so far
+	Frontier int    // the number of states waiting to be expanded
+	Best     int    // the best score found so far
+}
+
+// Reporter receives progress reports.
+type Reporter interface {
+	Report(Report)
+}
+
+// ReporterFunc is an adapter to allow the use of an ordinary function as a
+// Reporter.
+type ReporterFunc func(Report)
+
+// Report implements Reporter.
+func (f ReporterFunc) Report(r Report) { f(r) }
+
+type contextKey struct{}
+
+// WithReporter returns a copy of ctx that carries the given reporter.
+func WithReporter(ctx context.Context, r Reporter) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the reporter carried by ctx, or nil if there isn't one.
+func FromContext(ctx context.Context) Reporter {
+	r, _ := ctx.Value(contextKey{}).(Reporter)
+	return r
+}
+
+// Interval is the number of states that a Meter counts between each report.
+const Interval = 1 << 12
+
+// Meter counts the states expanded by a search.
+type Meter struct {
+	ctx      context.Context
+	reporter Reporter
+	curr     Report
+}
+
+// NewMeter returns a Meter for a search.  The stage may be empty.
+func NewMeter(ctx context.Context, stage string) *Meter {
+	return &Meter{
+		ctx:      ctx,
+		reporter: FromContext(ctx),
+		curr:     Report{Stage: stage},
+	}
+}
+
+// Expand records that the search has expanded one more state, and how many
+// states are in the frontier and the best score so far.  Every Interval
+// states, it sends a report and returns the context's error, if any.  It also
+// checks the context for the first state, so that a search which is started
+// after its context is cancelled stops straight away.
+func (m *Meter) Expand(frontier, best int) error {
+	m.curr.Expanded++
+	if m.curr.Expanded == 1 {
+		return m.ctx.Err()
+	}
+	if m.curr.Expanded%Interval != 0 {
+		return nil
+	}
+
+	m.curr.Frontier, m.curr.Best = frontier, best
+	if m.reporter != nil {
+		m.reporter.Report(m.curr)
+	}
+	return m.ctx.Err()
+}
+
+// Done sends a final report at the end of a search.
+func (m *Meter) Done(frontier, best int) {
+	m.curr.Frontier, m.curr.Best = frontier, best
+	if m.reporter != nil {
+		m.reporter.Report(m.curr)
+	}
+}
+
+// Expanded returns the number of states that have been expanded so far.
+func (m *Meter) Expanded() int {
+	return m.curr.Expanded
+}
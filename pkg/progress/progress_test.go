@@ -0,0 +1,57 @@
+package progress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeter(t *testing.T) {
+	t.Parallel()
+
+	var got []Report
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = WithReporter(ctx, ReporterFunc(func(r Report) { got = append(got, r) }))
+
+	m := NewMeter(ctx, "test")
+	for i := 1; i <= 2*Interval; i++ {
+		require.NoError(t, m.Expand(i, 10*i))
+	}
+	m.Done(0, 99)
+
+	assert.Equal(t, []Report{
+		{Stage: "test", Expanded: Interval, Frontier: Interval, Best: 10 * Interval},
+		{Stage: "test", Expanded: 2 * Interval, Frontier: 2 * Interval, Best: 20 * Interval},
+		{Stage: "test", Expanded: 2 * Interval, Frontier: 0, Best: 99},
+	}, got)
+
+	cancel()
+	var err error
+	for i := 0; i < Interval && err == nil; i++ {
+		err = m.Expand(0, 0)
+	}
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, FromContext(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, NewMeter(ctx, "").Expand(0, 0), context.Canceled,
+		"the first state checks the context")
+
+	// a meter without a reporter still counts, and still notices cancellation:
+	m := NewMeter(ctx, "")
+	var err error
+	for i := 0; i < Interval; i++ {
+		err = m.Expand(0, 0)
+	}
+	assert.Equal(t, Interval, m.Expanded())
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,72 @@
+package puzzle
+
+import (
+	"context"
+	"io"
+)
+
+// Some solvers can do more than find the answer: they can save images or
+// logs of how they got there, or animate the search as it runs.  They only
+// do so when the runner asks them to, using the options carried by the
+// context that is passed to each Solver.
+
+type (
+	outputDirKey struct{}
+	animationKey struct{}
+	paramsKey    struct{}
+)
+
+// WithOutputDir returns a copy of ctx that asks solvers to save any extra
+// files they can produce (such as images or logs) in the given directory.
+func WithOutputDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, outputDirKey{}, dir)
+}
+
+// OutputDir returns the directory carried by ctx, or an empty string if
+// solvers should not save any extra files.
+func OutputDir(ctx context.Context) string {
+	dir, _ := ctx.Value(outputDirKey{}).(string)
+	return dir
+}
+
+// WithAnimation returns a copy of ctx that asks solvers to animate their
+// work on the given writer, if they can.
+func WithAnimation(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, animationKey{}, w)
+}
+
+// Animation returns the writer carried by ctx, or nil if solvers should not
+// animate their work.
+func Animation(ctx context.Context) io.Writer {
+	w, _ := ctx.Value(animationKey{}).(io.Writer)
+	return w
+}
+
+// Params are named settings for the solvers, such as the size of the area to
+// search, which can differ between the example input and the real one.
+type Params map[string]int
+
+// WithParams returns a copy of ctx that carries the given params, as well as
+// any that ctx already carries.  Where both have a value for the same name,
+// the given params take precedence.
+func WithParams(ctx context.Context, params Params) context.Context {
+	prev, _ := ctx.Value(paramsKey{}).(Params)
+	merged := make(Params, len(prev)+len(params))
+	for name, val := range prev {
+		merged[name] = val
+	}
+	for name, val := range params {
+		merged[name] = val
+	}
+	return context.WithValue(ctx, paramsKey{}, merged)
+}
+
+// Param returns the value of the named param carried by ctx, or def if ctx
+// does not carry one.
+func Param(ctx context.Context, name string, def int) int {
+	params, _ := ctx.Value(paramsKey{}).(Params)
+	if val, ok := params[name]; ok {
+		return val
+	}
+	return def
+}
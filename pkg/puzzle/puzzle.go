@@ -0,0 +1,105 @@
+// Package puzzle provides a common interface for the solvers of each day's
+// puzzle, and a registry so that they can all be run from a single command.
+package puzzle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Solver solves one part of a day's puzzle, using the given input.
+// The answer is usually an int or a string.  Long-running solvers should
+// stop early when ctx is cancelled, and may report their progress using
+// the progress package.
+type Solver func(ctx context.Context, r io.Reader) (any, error)
+
+var (
+	_mu           sync.RWMutex
+	_registry     = make(map[int][]Solver)
+	_samples      = make(map[int]string)
+	_sampleParams = make(map[int]Params)
+)
+
+// Register makes the solvers for the given day available to the runner.
+// The solvers are given in order: part 1 first, followed by part 2.
+// A nil solver means that part of the puzzle has not been solved.
+//
+// Register panics if it is called twice for the same day.
+func Register(day int, parts ...Solver) {
+	_mu.Lock()
+	defer _mu.Unlock()
+
+	if _, dup := _registry[day]; dup {
+		panic(fmt.Sprintf("puzzle: Register called twice for day %d", day))
+	}
+	_registry[day] = parts
+}
+
+// RegisterSample records the example input from the given day's puzzle
+// description, so that the solvers can be run without a personal input.
+func RegisterSample(day int, sample string) {
+	_mu.Lock()
+	defer _mu.Unlock()
+	_samples[day] = sample
+}
+
+// RegisterSampleParams records the params that the solvers for the given day
+// need when they are run with the example input, such as a smaller area to
+// search than the one for the real input.
+func RegisterSampleParams(day int, params Params) {
+	_mu.Lock()
+	defer _mu.Unlock()
+	_sampleParams[day] = params
+}
+
+// SampleParams returns the params for the example input of the given day, or
+// nil if it doesn't have any.
+func SampleParams(day int) Params {
+	_mu.RLock()
+	defer _mu.RUnlock()
+	return _sampleParams[day]
+}
+
+// Sample returns the example input for the given day, and true iff there is one.
+func Sample(day int) (string, bool) {
+	_mu.RLock()
+	defer _mu.RUnlock()
+	s, ok := _samples[day]
+	return s, ok
+}
+
+// Lookup returns the solver for the given day and part (1-based), and true
+// iff that part of the puzzle has been solved.
+func Lookup(day, part int) (Solver, bool) {
+	_mu.RLock()
+	defer _mu.RUnlock()
+
+	parts, ok := _registry[day]
+	if !ok || part < 1 || part > len(parts) || parts[part-1] == nil {
+		return nil, false
+	}
+	return parts[part-1], true
+}
+
+// Parts returns the number of parts that have been registered for the given day.
+func Parts(day int) int {
+	_mu.RLock()
+	defer _mu.RUnlock()
+	return len(_registry[day])
+}
+
+// Days returns all of the registered days, in ascending order.
+func Days() []int {
+	_mu.RLock()
+	defer _mu.RUnlock()
+
+	days := make([]int, 0, len(_registry))
+	for d := range _registry {
+		days = append(days, d)
+	}
+	sort.Ints(days)
+	return days
+}
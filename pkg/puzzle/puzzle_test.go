@@ -0,0 +1,92 @@
+package puzzle
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister(t *testing.T) {
+	answer := func(n int) Solver {
+		return func(context.Context, io.Reader) (any, error) { return n, nil }
+	}
+
+	Register(101, answer(1), answer(2))
+	Register(100, nil, answer(4))
+
+	a := assert.New(t)
+	a.Equal([]int{100, 101}, Days())
+	a.Equal(2, Parts(100))
+	a.Equal(0, Parts(102))
+
+	s, ok := Lookup(101, 2)
+	require.True(t, ok)
+	got, err := s(context.Background(), nil)
+	require.NoError(t, err)
+	a.Equal(2, got)
+
+	_, ok = Lookup(100, 1)
+	a.False(ok, "a nil solver has not been solved")
+
+	_, ok = Lookup(101, 3)
+	a.False(ok, "day 101 only has two parts")
+
+	a.Panics(func() { Register(101, answer(5)) })
+}
+
+func TestSample(t *testing.T) {
+	RegisterSample(103, "1\n2\n3\n")
+
+	got, ok := Sample(103)
+	assert.True(t, ok)
+	assert.Equal(t, "1\n2\n3\n", got)
+
+	_, ok = Sample(104)
+	assert.False(t, ok)
+
+	RegisterSampleParams(103, Params{"row": 10})
+	assert.Equal(t, Params{"row": 10}, SampleParams(103))
+	assert.Nil(t, SampleParams(104))
+}
+
+func TestRun(t *testing.T) {
+	Register(105,
+		func(_ context.Context, r io.Reader) (any, error) {
+			b, err := io.ReadAll(r)
+			return len(b), err
+		},
+		nil)
+
+	got, err := Run(context.Background(), 105, 1, []byte("abc"))
+	require.NoError(t, err)
+
+	a := assert.New(t)
+	a.Equal(105, got.Day)
+	a.Equal(1, got.Part)
+	a.Equal("3", got.Answer)
+	a.Equal("ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", got.InputHash)
+
+	_, err = Run(context.Background(), 105, 2, nil)
+	a.ErrorIs(err, ErrNotSolved)
+}
+
+func TestOptions(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, OutputDir(ctx))
+	assert.Nil(t, Animation(ctx))
+
+	var sb strings.Builder
+	ctx = WithAnimation(WithOutputDir(ctx, "out"), &sb)
+	assert.Equal(t, "out", OutputDir(ctx))
+	assert.Equal(t, &sb, Animation(ctx))
+
+	assert.Equal(t, 7, Param(ctx, "row", 7))
+	ctx = WithParams(ctx, Params{"row": 10, "limit": 20})
+	ctx = WithParams(ctx, Params{"limit": 30})
+	assert.Equal(t, 10, Param(ctx, "row", 7))
+	assert.Equal(t, 30, Param(ctx, "limit", 7))
+}
@@ -0,0 +1,54 @@
+package puzzle
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotSolved is returned by Run when the given part has not been solved.
+var ErrNotSolved = errors.New("not solved")
+
+// Result is the outcome of running the solver for one part of a day's puzzle.
+type Result struct {
+	Day       int
+	Part      int
+	Answer    string
+	Duration  time.Duration
+	InputHash string // the hex-encoded SHA-256 hash of the input
+}
+
+// Run solves one part of the given day's puzzle using input, and returns
+// the answer along with the time it took.
+func Run(ctx context.Context, day, part int, input []byte) (Result, error) {
+	solve, ok := Lookup(day, part)
+	if !ok {
+		return Result{}, ErrNotSolved
+	}
+
+	start := time.Now()
+	answer, err := solve(ctx, bytes.NewReader(input))
+	elapsed := time.Since(start)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Day:       day,
+		Part:      part,
+		Answer:    fmt.Sprint(answer),
+		Duration:  elapsed,
+		InputHash: HashInput(input),
+	}, nil
+}
+
+// HashInput returns the hex-encoded SHA-256 hash of the given input,
+// so that results from different runs can be compared.
+func HashInput(input []byte) string {
+	sum := sha256.Sum256(input)
+	return hex.EncodeToString(sum[:])
+}
@@ -28,13 +28,6 @@ type Rope struct {
 	l     Logger
 }
 
-var (
-	_up    = v.Point{Y: 1}
-	_right = v.Point{X: 1}
-	_down  = v.Point{Y: -1}
-	_left  = v.Point{X: -1}
-)
-
 // Move the head of the rope by the given increment, and adjust the tail to follow.
 // Assumes that the step will always be one of up, down, left or right.
 func (r *Rope) Move(step v.Point) error {
@@ -58,38 +51,14 @@ func move(i int, step v.Point, knots []v.Point) error {
 	h2 := head.Add(step)
 	*head = h2
 
+	// the next knot only moves if it is no longer touching this one, and then
+	// it takes a single step (possibly diagonal) towards it:
 	diff := h2.Sub(next)
-	switch diff {
-	// base case - no further movement needed:
-	case v.Point{}, _up, _down, _left, _right,
-		v.Point{X: 1, Y: 1}, v.Point{X: 1, Y: -1},
-		v.Point{X: -1, Y: 1}, v.Point{X: -1, Y: -1}:
+	switch v.ChebyshevLength(diff) {
+	case 0, 1:
 		return nil
-
-	case v.Point{Y: 2}:
-		step = _up
-
-	case v.Point{X: 2}:
-		step = _right
-
-	case v.Point{Y: -2}:
-		step = _down
-
-	case v.Point{X: -2}:
-		step = _left
-
-	case v.Point{X: 2, Y: 1}, v.Point{X: 2, Y: 2}, v.Point{X: 1, Y: 2}:
-		step = _up.Add(_right)
-
-	case v.Point{X: 2, Y: -1}, v.Point{X: 2, Y: -2}, v.Point{X: 1, Y: -2}:
-		step = _down.Add(_right)
-
-	case v.Point{X: -2, Y: -1}, v.Point{X: -2, Y: -2}, v.Point{X: -1, Y: -2}:
-		step = _down.Add(_left)
-
-	case v.Point{X: -2, Y: 1}, v.Point{X: -2, Y: 2}, v.Point{X: -1, Y: 2}:
-		step = _up.Add(_left)
-
+	case 2:
+		step = diff.Sign()
 	default:
 		return fmt.Errorf("invalid step: %v gave difference of %v", step, diff)
 	}
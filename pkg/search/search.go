@@ -0,0 +1,187 @@
+// Package search finds the cheapest path through a graph, using
+// breadth-first search, Dijkstra's algorithm or A*.
+//
+// The graph is described by a Graph, which gives the neighbours of each
+// state (with the cost of moving to each one) and says which states are
+// goals.  The states themselves must be comparable, so that the search can
+// remember which ones it has already seen.
+//
+// Each search takes a context, which it uses to stop early and to report on
+// its progress (see the progress package).
+package search
+
+import (
+	"context"
+	"errors"
+
+	pq "github.com/nealmcc/aoc2022/pkg/collection/prioqueue"
+	"github.com/nealmcc/aoc2022/pkg/progress"
+)
+
+// ErrNoPath is returned when there is no path from the start to a goal.
+var ErrNoPath = errors.New("no path found")
+
+// Graph is a graph of states, to be searched.
+type Graph[S comparable] interface {
+	// Neighbours returns the edges leading out of the given state.
+	Neighbours(s S) []Edge[S]
+	// IsGoal reports whether the given state is a goal.
+	IsGoal(s S) bool
+}
+
+// Edge is a step from one state to another, with the cost of taking it.
+// Costs must not be negative.
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// Heuristic estimates the cost of the cheapest path from the given state to
+// a goal.  For A* to find the cheapest path, the estimate must never be more
+// than the true cost.
+type Heuristic[S comparable] func(s S) int
+
+// Funcs adapts a pair of ordinary functions to the Graph interface.
+type Funcs[S comparable] struct {
+	Next func(s S) []Edge[S]
+	Goal func(s S) bool
+}
+
+// Neighbours implements Graph.
+func (f Funcs[S]) Neighbours(s S) []Edge[S] { return f.Next(s) }
+
+// IsGoal implements Graph.
+func (f Funcs[S]) IsGoal(s S) bool { return f.Goal(s) }
+
+// Result is the outcome of a successful search.
+type Result[S comparable] struct {
+	Cost int // the total cost of the path
+	Path []S // the states along the path, from a start to the goal
+}
+
+// BFS finds the path from any of the start states to a goal using the fewest
+// steps, ignoring the cost of each edge.  The cost of the result is the
+// number of steps taken.
+func BFS[S comparable](ctx context.Context, g Graph[S], starts ...S) (Result[S], error) {
+	prev := make(map[S]S)
+	seen := make(map[S]bool, len(starts))
+	frontier := make([]S, 0, len(starts))
+	for _, s := range starts {
+		if !seen[s] {
+			seen[s] = true
+			frontier = append(frontier, s)
+		}
+	}
+
+	meter := progress.NewMeter(ctx, "")
+	for depth := 0; len(frontier) > 0; depth++ {
+		next := make([]S, 0, len(frontier))
+		for i, curr := range frontier {
+			if err := meter.Expand(len(frontier)-i-1+len(next), depth); err != nil {
+				return Result[S]{}, err
+			}
+
+			if g.IsGoal(curr) {
+				meter.Done(len(frontier)-i-1+len(next), depth)
+				return Result[S]{Cost: depth, Path: walk(prev, curr)}, nil
+			}
+
+			for _, e := range g.Neighbours(curr) {
+				if seen[e.To] {
+					continue
+				}
+				seen[e.To] = true
+				prev[e.To] = curr
+				next = append(next, e.To)
+			}
+		}
+		frontier = next
+	}
+
+	return Result[S]{}, ErrNoPath
+}
+
+// Dijkstra finds the cheapest path from any of the start states to a goal,
+// using Dijkstra's algorithm.
+func Dijkstra[S comparable](ctx context.Context, g Graph[S], starts ...S) (Result[S], error) {
+	return AStar(ctx, g, nil, starts...)
+}
+
+// AStar finds the cheapest path from any of the start states to a goal,
+// using the A* algorithm with the given heuristic.  A nil heuristic is the
+// same as one that always returns zero, which makes this Dijkstra's
+// algorithm.
+func AStar[S comparable](ctx context.Context, g Graph[S], h Heuristic[S], starts ...S) (Result[S], error) {
+	if h == nil {
+		h = func(S) int { return 0 }
+	}
+
+	var (
+		cost = make(map[S]int, len(starts)) // the cheapest known cost to reach each state
+		prev = make(map[S]S)                // the previous state on that path
+		done = make(map[S]bool)             // states we are finished with
+		q    = pq.NewKeyed[S](_order)       // states to examine, by estimated total cost
+	)
+
+	for _, s := range starts {
+		if q.Contains(s) {
+			continue
+		}
+		cost[s] = 0
+		q.PushOrUpdate(s, pq.Pair[int, int]{Major: h(s)})
+	}
+
+	meter := progress.NewMeter(ctx, "")
+	for q.Len() > 0 {
+		curr, _, _ := q.Pop()
+		done[curr] = true
+
+		if err := meter.Expand(q.Len(), cost[curr]); err != nil {
+			return Result[S]{}, err
+		}
+
+		if g.IsGoal(curr) {
+			meter.Done(q.Len(), cost[curr])
+			return Result[S]{Cost: cost[curr], Path: walk(prev, curr)}, nil
+		}
+
+		for _, e := range g.Neighbours(curr) {
+			if done[e.To] {
+				continue
+			}
+
+			alt := cost[curr] + e.Cost
+			if c, ok := cost[e.To]; ok && alt >= c {
+				continue
+			}
+			cost[e.To] = alt
+			prev[e.To] = curr
+			q.PushOrUpdate(e.To, pq.Pair[int, int]{Major: alt + h(e.To), Minor: alt})
+		}
+	}
+
+	return Result[S]{}, ErrNoPath
+}
+
+// _order is the order in which A* examines states: the lowest estimated
+// total cost first, and then the highest cost so far, which prefers states
+// that are closer to a goal.
+var _order = pq.ThenBy(pq.MinFirst[int], pq.MaxFirst[int])
+
+// walk follows the previous states back from the goal, and returns the path
+// in the order it was taken.
+func walk[S comparable](prev map[S]S, goal S) []S {
+	path := []S{goal}
+	for {
+		p, ok := prev[path[len(path)-1]]
+		if !ok {
+			break
+		}
+		path = append(path, p)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
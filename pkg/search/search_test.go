@@ -0,0 +1,133 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// _weighted is a small graph where the path with the fewest steps
+// (a-d-e) is not the cheapest one (a-b-c-e).
+var _weighted = map[string][]Edge[string]{
+	"a": {{To: "b", Cost: 1}, {To: "d", Cost: 5}},
+	"b": {{To: "c", Cost: 1}},
+	"c": {{To: "e", Cost: 1}},
+	"d": {{To: "e", Cost: 1}},
+	"f": {{To: "e", Cost: 1}},
+}
+
+func weighted(goal string) Graph[string] {
+	return Funcs[string]{
+		Next: func(s string) []Edge[string] { return _weighted[s] },
+		Goal: func(s string) bool { return s == goal },
+	}
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	type searchFunc func(context.Context, Graph[string], ...string) (Result[string], error)
+
+	aStar := func(ctx context.Context, g Graph[string], starts ...string) (Result[string], error) {
+		h := func(s string) int {
+			if s == "e" {
+				return 0
+			}
+			return 1
+		}
+		return AStar(ctx, g, h, starts...)
+	}
+
+	tt := []struct {
+		name    string
+		search  searchFunc
+		starts  []string
+		goal    string
+		want    Result[string]
+		wantErr error
+	}{
+		{
+			name:   "bfs takes the fewest steps",
+			search: BFS[string],
+			starts: []string{"a"},
+			goal:   "e",
+			want:   Result[string]{Cost: 2, Path: []string{"a", "d", "e"}},
+		},
+		{
+			name:   "dijkstra takes the cheapest path",
+			search: Dijkstra[string],
+			starts: []string{"a"},
+			goal:   "e",
+			want:   Result[string]{Cost: 3, Path: []string{"a", "b", "c", "e"}},
+		},
+		{
+			name:   "a* takes the cheapest path",
+			search: aStar,
+			starts: []string{"a"},
+			goal:   "e",
+			want:   Result[string]{Cost: 3, Path: []string{"a", "b", "c", "e"}},
+		},
+		{
+			name:   "the start is the goal",
+			search: Dijkstra[string],
+			starts: []string{"a"},
+			goal:   "a",
+			want:   Result[string]{Cost: 0, Path: []string{"a"}},
+		},
+		{
+			name:   "dijkstra from the nearest of several starts",
+			search: Dijkstra[string],
+			starts: []string{"a", "f"},
+			goal:   "e",
+			want:   Result[string]{Cost: 1, Path: []string{"f", "e"}},
+		},
+		{
+			name:   "bfs from the nearest of several starts",
+			search: BFS[string],
+			starts: []string{"a", "f"},
+			goal:   "e",
+			want:   Result[string]{Cost: 1, Path: []string{"f", "e"}},
+		},
+		{
+			name:    "dijkstra with no path",
+			search:  Dijkstra[string],
+			starts:  []string{"e"},
+			goal:    "a",
+			wantErr: ErrNoPath,
+		},
+		{
+			name:    "bfs with no path",
+			search:  BFS[string],
+			starts:  []string{"e"},
+			goal:    "a",
+			wantErr: ErrNoPath,
+		},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.search(context.Background(), weighted(tc.goal), tc.starts...)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAStar_cancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := AStar(ctx, weighted("e"), nil, "a")
+	assert.ErrorIs(t, err, context.Canceled)
+}
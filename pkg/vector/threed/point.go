@@ -0,0 +1,146 @@
+// Package threed models three-dimension vectors.
+package threed
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Point is a 3-dimensional integer coordinate.
+type Point struct {
+	X int
+	Y int
+	Z int
+}
+
+// Parse the given text in the form x,y,z as a point.
+// The text must have three numbers separated by commas.
+func (p *Point) Parse(b []byte) error {
+	parts := bytes.Split(b, []byte{','})
+	if len(parts) != 3 {
+		return errors.New("parse requires three parts")
+	}
+
+	var err error
+	if p.X, err = strconv.Atoi(string(parts[0])); err != nil {
+		return fmt.Errorf("invalid value for x: %w", err)
+	}
+
+	if p.Y, err = strconv.Atoi(string(parts[1])); err != nil {
+		return fmt.Errorf("invalid value for y: %w", err)
+	}
+
+	if p.Z, err = strconv.Atoi(string(parts[2])); err != nil {
+		return fmt.Errorf("invalid value for z: %w", err)
+	}
+
+	return nil
+}
+
+// Coords returns the coordinates of this point as a slice, X first.
+func (p Point) Coords() []int {
+	return []int{p.X, p.Y, p.Z}
+}
+
+// Add returns the vector sum of a + b.
+func (a Point) Add(b Point) Point {
+	return Point{
+		X: a.X + b.X,
+		Y: a.Y + b.Y,
+		Z: a.Z + b.Z,
+	}
+}
+
+// Sub returns the vector difference of a - b.
+func (a Point) Sub(b Point) Point {
+	return Point{
+		X: a.X - b.X,
+		Y: a.Y - b.Y,
+		Z: a.Z - b.Z,
+	}
+}
+
+// Times returns a copy of this Point scaled by n.
+func (a Point) Times(n int) Point {
+	return Point{
+		X: a.X * n,
+		Y: a.Y * n,
+		Z: a.Z * n,
+	}
+}
+
+// Dot returns the dot product of vectors a and b.
+func (a Point) Dot(b Point) int {
+	return a.X*b.X + a.Y*b.Y + a.Z*b.Z
+}
+
+// Cross returns the cross product a x b, which is perpendicular to both.
+func (a Point) Cross(b Point) Point {
+	return Point{
+		X: a.Y*b.Z - a.Z*b.Y,
+		Y: a.Z*b.X - a.X*b.Z,
+		Z: a.X*b.Y - a.Y*b.X,
+	}
+}
+
+func (a Point) String() string {
+	return fmt.Sprintf("(%d, %d, %d)", a.X, a.Y, a.Z)
+}
+
+// ManhattanLength returns the sum of the absolute values of p's coordinates.
+func ManhattanLength(p Point) int {
+	return abs(p.X) + abs(p.Y) + abs(p.Z)
+}
+
+// Axes returns the unit vectors along the positive X, Y and Z axes.
+func Axes() []Point {
+	return []Point{
+		{X: 1},
+		{Y: 1},
+		{Z: 1},
+	}
+}
+
+// Neighbours6 returns the six points that share a face with this one.
+func (p Point) Neighbours6() []Point {
+	return p.neighbours(1)
+}
+
+// Neighbours18 returns the eighteen points that share a face or an edge with
+// this one.
+func (p Point) Neighbours18() []Point {
+	return p.neighbours(2)
+}
+
+// Neighbours26 returns the twenty-six points that share a face, an edge or a
+// corner with this one.
+func (p Point) Neighbours26() []Point {
+	return p.neighbours(3)
+}
+
+// neighbours returns the points around this one that differ from it by one
+// in at most n of their coordinates.
+func (p Point) neighbours(n int) []Point {
+	res := make([]Point, 0, 26)
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			for dz := -1; dz <= 1; dz++ {
+				d := Point{X: dx, Y: dy, Z: dz}
+				if l := ManhattanLength(d); l > 0 && l <= n {
+					res = append(res, p.Add(d))
+				}
+			}
+		}
+	}
+	return res
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
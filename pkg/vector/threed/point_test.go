@@ -0,0 +1,117 @@
+package threed
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExamplePoint_Cross() {
+	x, y := Point{X: 1}, Point{Y: 1}
+	fmt.Println(x.Cross(y), y.Cross(x))
+	// Output: (0, 0, 1) (0, 0, -1)
+}
+
+func TestPoint_Parse(t *testing.T) {
+	tt := []struct {
+		in      string
+		want    Point
+		wantErr bool
+	}{
+		{in: "2,-3,14", want: Point{X: 2, Y: -3, Z: 14}},
+		{in: "0,0,0", want: Point{}},
+		{in: "1,2", wantErr: true},
+		{in: "1,2,3,4", wantErr: true},
+		{in: "1,b,3", wantErr: true},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in, func(t *testing.T) {
+			t.Parallel()
+
+			var got Point
+			err := got.Parse([]byte(tc.in))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Parse(%q) error = %v ; want error: %t", tc.in, err, tc.wantErr)
+			}
+			if !tc.wantErr && got != tc.want {
+				t.Logf("Parse(%q) = %v ; want %v", tc.in, got, tc.want)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestPoint_arithmetic(t *testing.T) {
+	t.Parallel()
+
+	a, b := Point{X: 1, Y: 2, Z: 3}, Point{X: -4, Y: 5, Z: 0}
+
+	tt := []struct {
+		name string
+		got  Point
+		want Point
+	}{
+		{"add", a.Add(b), Point{X: -3, Y: 7, Z: 3}},
+		{"sub", a.Sub(b), Point{X: 5, Y: -3, Z: 3}},
+		{"times", a.Times(-2), Point{X: -2, Y: -4, Z: -6}},
+		{"cross", a.Cross(b), Point{X: -15, Y: -12, Z: 13}},
+	}
+
+	for _, tc := range tt {
+		if tc.got != tc.want {
+			t.Logf("%s: got %v ; want %v", tc.name, tc.got, tc.want)
+			t.Fail()
+		}
+	}
+
+	if got := a.Dot(b); got != 6 {
+		t.Logf("%v.Dot(%v) = %d ; want 6", a, b, got)
+		t.Fail()
+	}
+	if got := a.Cross(b).Dot(a); got != 0 {
+		t.Logf("the cross product should be perpendicular to a, but the dot product is %d", got)
+		t.Fail()
+	}
+	if got := ManhattanLength(b); got != 9 {
+		t.Logf("ManhattanLength(%v) = %d ; want 9", b, got)
+		t.Fail()
+	}
+}
+
+func TestPoint_Neighbours(t *testing.T) {
+	t.Parallel()
+
+	p := Point{X: 10, Y: -10, Z: 0}
+
+	tt := []struct {
+		name   string
+		got    []Point
+		want   int
+		maxLen int
+	}{
+		{name: "faces", got: p.Neighbours6(), want: 6, maxLen: 1},
+		{name: "faces and edges", got: p.Neighbours18(), want: 18, maxLen: 2},
+		{name: "faces, edges and corners", got: p.Neighbours26(), want: 26, maxLen: 3},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if len(tc.got) != tc.want {
+				t.Fatalf("got %d neighbours ; want %d", len(tc.got), tc.want)
+			}
+			seen := make(map[Point]bool)
+			for _, n := range tc.got {
+				l := ManhattanLength(n.Sub(p))
+				if l == 0 || l > tc.maxLen || seen[n] {
+					t.Logf("unexpected neighbour %v", n)
+					t.Fail()
+				}
+				seen[n] = true
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package threed
+
+// Rotation is a rotation by a multiple of 90 degrees about each axis, such as
+// turning a cube to rest on a different face.  Each row gives the new value
+// of one coordinate, as a combination of the old ones.
+type Rotation [3]Point
+
+// Identity is the rotation that leaves every point where it is.
+var Identity = Rotation{
+	{X: 1},
+	{Y: 1},
+	{Z: 1},
+}
+
+// _rotations are the 24 axis-aligned rotations, starting with the identity.
+var _rotations = func() []Rotation {
+	res := make([]Rotation, 0, 24)
+	// each axis can be mapped to any axis, in either direction, so long as
+	// the result is not a reflection:
+	for _, x := range signedAxes() {
+		for _, y := range signedAxes() {
+			if x.Dot(y) != 0 {
+				continue
+			}
+			// fixing z as x cross y keeps the axes right-handed.
+			z := x.Cross(y)
+			res = append(res, Rotation{x, y, z}.transpose())
+		}
+	}
+	return res
+}()
+
+// Rotations returns the 24 axis-aligned rotations, starting with the
+// identity.
+func Rotations() []Rotation {
+	res := make([]Rotation, len(_rotations))
+	copy(res, _rotations)
+	return res
+}
+
+// Apply returns p rotated by r.
+func (r Rotation) Apply(p Point) Point {
+	return Point{
+		X: r[0].Dot(p),
+		Y: r[1].Dot(p),
+		Z: r[2].Dot(p),
+	}
+}
+
+// Then returns the rotation that first applies r, and then applies next.
+func (r Rotation) Then(next Rotation) Rotation {
+	cols := r.transpose()
+	var res Rotation
+	for i, row := range next {
+		res[i] = Point{
+			X: row.Dot(cols[0]),
+			Y: row.Dot(cols[1]),
+			Z: row.Dot(cols[2]),
+		}
+	}
+	return res
+}
+
+// Inverse returns the rotation that undoes r.
+func (r Rotation) Inverse() Rotation {
+	// rotation matrices are orthogonal, so the inverse is the transpose.
+	return r.transpose()
+}
+
+// transpose swaps the rows and columns of r.
+func (r Rotation) transpose() Rotation {
+	return Rotation{
+		{X: r[0].X, Y: r[1].X, Z: r[2].X},
+		{X: r[0].Y, Y: r[1].Y, Z: r[2].Y},
+		{X: r[0].Z, Y: r[1].Z, Z: r[2].Z},
+	}
+}
+
+// signedAxes returns the unit vectors along each axis, in both directions.
+func signedAxes() []Point {
+	res := make([]Point, 0, 6)
+	for _, a := range Axes() {
+		res = append(res, a, a.Times(-1))
+	}
+	return res
+}
@@ -0,0 +1,59 @@
+package threed
+
+import "testing"
+
+func TestRotations(t *testing.T) {
+	t.Parallel()
+
+	rots := Rotations()
+	if len(rots) != 24 {
+		t.Fatalf("got %d rotations ; want 24", len(rots))
+	}
+	if rots[0] != Identity {
+		t.Logf("the first rotation is %v ; want the identity", rots[0])
+		t.Fail()
+	}
+
+	// an asymmetric point has a different image under each rotation:
+	p := Point{X: 1, Y: 2, Z: 3}
+	seen := make(map[Point]bool)
+	for _, r := range rots {
+		q := r.Apply(p)
+		if seen[q] {
+			t.Logf("%v is repeated", q)
+			t.Fail()
+		}
+		seen[q] = true
+
+		// rotations keep the axes right-handed:
+		x, y, z := r.Apply(Point{X: 1}), r.Apply(Point{Y: 1}), r.Apply(Point{Z: 1})
+		if x.Cross(y) != z {
+			t.Logf("%v is a reflection", r)
+			t.Fail()
+		}
+
+		if back := r.Inverse().Apply(q); back != p {
+			t.Logf("the inverse of %v took %v back to %v", r, q, back)
+			t.Fail()
+		}
+	}
+}
+
+func TestRotation_Then(t *testing.T) {
+	t.Parallel()
+
+	// a quarter turn about z takes x to y, and a quarter turn about x takes
+	// y to z:
+	aboutZ := Rotation{{Y: -1}, {X: 1}, {Z: 1}}
+	aboutX := Rotation{{X: 1}, {Z: -1}, {Y: 1}}
+
+	p := Point{X: 1}
+	if got, want := aboutZ.Then(aboutX).Apply(p), (Point{Z: 1}); got != want {
+		t.Logf("aboutZ.Then(aboutX).Apply(%v) = %v ; want %v", p, got, want)
+		t.Fail()
+	}
+	if got := aboutZ.Then(aboutZ).Then(aboutZ).Then(aboutZ); got != Identity {
+		t.Logf("four quarter turns gave %v ; want the identity", got)
+		t.Fail()
+	}
+}
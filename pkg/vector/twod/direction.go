@@ -0,0 +1,59 @@
+package twod
+
+// Direction is one of the eight points of the compass.  The directions are
+// numbered clockwise, starting from North.
+type Direction int
+
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+// Cardinals are the four main points of the compass, in clockwise order.
+var Cardinals = [4]Direction{North, East, South, West}
+
+// Vector returns the unit step in this direction.  Like the puzzle grids,
+// Y increases downwards, so North is {X: 0, Y: -1}.
+func (d Direction) Vector() Point {
+	return [...]Point{
+		{Y: -1},
+		{X: 1, Y: -1},
+		{X: 1},
+		{X: 1, Y: 1},
+		{Y: 1},
+		{X: -1, Y: 1},
+		{X: -1},
+		{X: -1, Y: -1},
+	}[d]
+}
+
+// Rotate returns the direction n eighths of a turn clockwise from this one.
+// Use a negative n to turn anticlockwise.
+func (d Direction) Rotate(n int) Direction {
+	return Direction(((int(d)+n)%8 + 8) % 8)
+}
+
+// TurnLeft returns the direction a quarter turn anticlockwise from this one.
+func (d Direction) TurnLeft() Direction {
+	return d.Rotate(-2)
+}
+
+// TurnRight returns the direction a quarter turn clockwise from this one.
+func (d Direction) TurnRight() Direction {
+	return d.Rotate(2)
+}
+
+// Opposite returns the direction half a turn from this one.
+func (d Direction) Opposite() Direction {
+	return d.Rotate(4)
+}
+
+func (d Direction) String() string {
+	return [...]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}[d]
+}
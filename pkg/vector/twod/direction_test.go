@@ -0,0 +1,50 @@
+package twod
+
+import "testing"
+
+func TestDirection(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		in       Direction
+		vector   Point
+		left     Direction
+		right    Direction
+		opposite Direction
+	}{
+		{in: North, vector: Point{Y: -1}, left: West, right: East, opposite: South},
+		{in: NorthEast, vector: Point{X: 1, Y: -1}, left: NorthWest, right: SouthEast, opposite: SouthWest},
+		{in: East, vector: Point{X: 1}, left: North, right: South, opposite: West},
+		{in: South, vector: Point{Y: 1}, left: East, right: West, opposite: North},
+		{in: West, vector: Point{X: -1}, left: South, right: North, opposite: East},
+		{in: NorthWest, vector: Point{X: -1, Y: -1}, left: SouthWest, right: NorthEast, opposite: SouthEast},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.in.String(), func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.in.Vector(); got != tc.vector {
+				t.Logf("%s.Vector() = %v ; want %v", tc.in, got, tc.vector)
+				t.Fail()
+			}
+			if got := tc.in.TurnLeft(); got != tc.left {
+				t.Logf("%s.TurnLeft() = %s ; want %s", tc.in, got, tc.left)
+				t.Fail()
+			}
+			if got := tc.in.TurnRight(); got != tc.right {
+				t.Logf("%s.TurnRight() = %s ; want %s", tc.in, got, tc.right)
+				t.Fail()
+			}
+			if got := tc.in.Opposite(); got != tc.opposite {
+				t.Logf("%s.Opposite() = %s ; want %s", tc.in, got, tc.opposite)
+				t.Fail()
+			}
+			if got := tc.in.Rotate(-1).Rotate(9); got != tc.in {
+				t.Logf("%s.Rotate(-1).Rotate(9) = %s ; want %s", tc.in, got, tc.in)
+				t.Fail()
+			}
+		})
+	}
+}
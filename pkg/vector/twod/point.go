@@ -36,6 +36,11 @@ func (p *Point) Parse(b []byte) error {
 	return nil
 }
 
+// Coords returns the coordinates of this point as a slice, X first.
+func (p Point) Coords() []int {
+	return []int{p.X, p.Y}
+}
+
 // Add returns the vector sum of a + b.
 func (a Point) Add(b Point) Point {
 	return Point{
@@ -70,6 +75,45 @@ func (p Point) Neighbours4() []Point {
 	}
 }
 
+// Neighbours8 returns the eight points adjacent to this one, including the
+// diagonals, in reading order.
+func (p Point) Neighbours8() []Point {
+	return []Point{
+		{X: p.X - 1, Y: p.Y - 1}, {X: p.X, Y: p.Y - 1}, {X: p.X + 1, Y: p.Y - 1},
+		{X: p.X - 1, Y: p.Y}, {X: p.X + 1, Y: p.Y},
+		{X: p.X - 1, Y: p.Y + 1}, {X: p.X, Y: p.Y + 1}, {X: p.X + 1, Y: p.Y + 1},
+	}
+}
+
+// Sign returns the vector with the sign (-1, 0 or 1) of each of a's
+// components.  So b.Sub(a).Sign() is the single step, possibly diagonal,
+// from a towards b.
+func (a Point) Sign() Point {
+	return Point{X: sign(a.X), Y: sign(a.Y)}
+}
+
+// Min returns the point with the smaller of each of the components of a and b.
+func (a Point) Min(b Point) Point {
+	if b.X < a.X {
+		a.X = b.X
+	}
+	if b.Y < a.Y {
+		a.Y = b.Y
+	}
+	return a
+}
+
+// Max returns the point with the larger of each of the components of a and b.
+func (a Point) Max(b Point) Point {
+	if b.X > a.X {
+		a.X = b.X
+	}
+	if b.Y > a.Y {
+		a.Y = b.Y
+	}
+	return a
+}
+
 // Reduce returns the shortest vector with the same slope as this one
 // that can still be represented with integer values for X and Y.
 // Also returns the largest positive integer that evenly divides this one.
@@ -116,6 +160,22 @@ func ManhattanLength(p Point) int {
 	return p.X + p.Y
 }
 
+// ChebyshevLength returns the larger of the absolute values of p's
+// components: the number of king's moves from the origin to p.
+func ChebyshevLength(p Point) int {
+	x, y := p.X, p.Y
+	if x < 0 {
+		x *= -1
+	}
+	if y < 0 {
+		y *= -1
+	}
+	if x > y {
+		return x
+	}
+	return y
+}
+
 // Rot90 returns this vector rotated 90 degrees.
 // With Y up, this is Left. With Y down, this is Light.
 func (p Point) Rot90() Point {
@@ -157,3 +217,14 @@ func gcd(a, b int) int {
 	}
 	return a
 }
+
+// sign returns -1, 0 or 1 for negative, zero or positive n.
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
@@ -140,3 +140,72 @@ func TestRot270(t *testing.T) {
 		})
 	}
 }
+
+func TestPoint_Neighbours8(t *testing.T) {
+	t.Parallel()
+
+	p := Point{X: 3, Y: -2}
+	got := p.Neighbours8()
+	if len(got) != 8 {
+		t.Fatalf("got %d neighbours ; want 8", len(got))
+	}
+
+	seen := make(map[Point]bool)
+	for _, n := range got {
+		if ChebyshevLength(n.Sub(p)) != 1 || seen[n] {
+			t.Logf("unexpected neighbour %v", n)
+			t.Fail()
+		}
+		seen[n] = true
+	}
+}
+
+func TestChebyshevLength(t *testing.T) {
+	tt := []struct {
+		name string
+		in   Point
+		want int
+	}{
+		{"zero vector has zero length", Point{}, 0},
+		{"diagonal", Point{-3, 3}, 3},
+		{"wider than tall", Point{-7, 2}, 7},
+		{"taller than wide", Point{1, -9}, 9},
+	}
+
+	for _, tc := range tt {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := ChebyshevLength(tc.in)
+			if got != tc.want {
+				t.Logf("ChebyshevLength(%v) = %d; want %d", tc.in, got, tc.want)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestPoint_componentWise(t *testing.T) {
+	t.Parallel()
+
+	a, b := Point{X: -4, Y: 7}, Point{X: 2, Y: 0}
+
+	tt := []struct {
+		name string
+		got  Point
+		want Point
+	}{
+		{"sign", a.Sign(), Point{X: -1, Y: 1}},
+		{"sign of zero", Point{}.Sign(), Point{}},
+		{"step towards", b.Sub(a).Sign(), Point{X: 1, Y: -1}},
+		{"min", a.Min(b), Point{X: -4, Y: 0}},
+		{"max", a.Max(b), Point{X: 2, Y: 7}},
+	}
+
+	for _, tc := range tt {
+		if tc.got != tc.want {
+			t.Logf("%s: got %v ; want %v", tc.name, tc.got, tc.want)
+			t.Fail()
+		}
+	}
+}